@@ -0,0 +1,44 @@
+// Command seal-secrets creates a secrets file for the encrypted secret
+// provider. It reads a JSON object of configuration key to secret from stdin
+// and writes it encrypted with the base64 key in SECRETS_KEY:
+//
+//	echo '{"DB.PG.WRITE.PASSWORD":"..."}' | SECRETS_KEY=... go run ./cmd/seal-secrets secrets.enc
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Getenv("SECRETS_KEY")); err != nil {
+		fmt.Fprintln(os.Stderr, "seal-secrets:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, encodedKey string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: seal-secrets <output file>")
+	}
+
+	key, err := configs.DecodeSecretsKey(encodedKey)
+	if err != nil {
+		return err
+	}
+
+	var secrets map[string]string
+	if err := json.NewDecoder(stdin).Decode(&secrets); err != nil {
+		return fmt.Errorf("reading secrets from stdin: %w", err)
+	}
+
+	sealed, err := configs.EncryptSecrets(secrets, key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(args[0], sealed, 0o600)
+}
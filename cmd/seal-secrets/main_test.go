@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+)
+
+func TestSealedFileOpensWithTheSameKey(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+
+	err := run([]string{path}, strings.NewReader(`{"DB.PG.WRITE.PASSWORD":"hunter2"}`), key)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	decoded, err := configs.DecodeSecretsKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider, err := configs.NewEncryptedFileSecretProvider(path, decoded)
+	if err != nil {
+		t.Fatalf("opening the sealed file: %v", err)
+	}
+	if value, ok, err := provider.Secret("DB.PG.WRITE.PASSWORD"); err != nil || !ok || value != "hunter2" {
+		t.Errorf("Secret = %q, %v, %v, want hunter2", value, ok, err)
+	}
+}
+
+func TestRunRejectsBadInput(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		key   string
+	}{
+		{"no output file", nil, `{}`, key},
+		{"key not base64", []string{path}, `{}`, "not base64!"},
+		{"short key", []string{path}, `{}`, base64.StdEncoding.EncodeToString([]byte("short"))},
+		{"not a JSON object", []string{path}, `["hunter2"]`, key},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(tt.args, strings.NewReader(tt.stdin), tt.key); err == nil {
+				t.Error("run succeeded, want an error")
+			}
+		})
+	}
+}
@@ -12,87 +12,203 @@ import (
 // variables.
 type Config struct {
 	App struct {
-		CORS struct {
-			AllowCredentials bool     `mapstructure:"ALLOW_CREDENTIALS"`
-			AllowedHeaders   []string `mapstructure:"ALLOWED_HEADERS"`
-			AllowedMethods   []string `mapstructure:"ALLOWED_METHODS"`
-			AllowedOrigins   []string `mapstructure:"ALLOWED_ORIGINS"`
-			Enable           bool     `mapstructure:"ENABLE"`
-			MaxAgeSeconds    int      `mapstructure:"MAX_AGE_SECONDS"`
-		}
+		CORS     CORS
 		Name     string `mapstructure:"NAME"`
 		Revision string `mapstructure:"REVISION"`
 		URL      string `mapstructure:"URL"`
 	}
 
+	// Auth signs the access tokens returned by a login. AdminRoleID is the
+	// role allowed to read the audit trail.
+	Auth struct {
+		TokenSecret Secret        `mapstructure:"TOKEN_SECRET"`
+		TokenTTL    time.Duration `mapstructure:"TOKEN_TTL"`
+		AdminRoleID int           `mapstructure:"ADMIN_ROLE_ID"`
+	}
+
 	Cache struct {
 		Redis struct {
 			Primary struct {
 				Host     string `mapstructure:"HOST"`
 				Port     string `mapstructure:"PORT"`
-				Password string `mapstructure:"PASSWORD"`
+				Password Secret `mapstructure:"PASSWORD"`
 			}
 		}
 	}
 	DB struct {
 		Postgres struct {
-			Read struct {
-				Host            string        `mapstructure:"HOST"`
-				Port            string        `mapstructure:"PORT"`
-				User            string        `mapstructure:"USER"`
-				Password        string        `mapstructure:"PASSWORD"`
-				Name            string        `mapstructure:"NAME"`
-				SSLMode         string        `mapstructure:"SSLMODE"`
-				MaxConnLifetime time.Duration `mapstructure:"MAX_CONNECTION_LIFETIME"`
-				MaxIdleConn     int           `mapstructure:"MAX_IDLE_CONNECTION"`
-				MaxOpenConn     int           `mapstructure:"MAX_OPEN_CONNECTION"`
+			Read    PostgresPool
+			Write   PostgresPool
+			Connect struct {
+				MaxWait        time.Duration `mapstructure:"MAX_WAIT"`
+				InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+				MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
 			}
-			Write struct {
-				Host            string        `mapstructure:"HOST"`
-				Port            string        `mapstructure:"PORT"`
-				User            string        `mapstructure:"USER"`
-				Password        string        `mapstructure:"PASSWORD"`
-				Name            string        `mapstructure:"NAME"`
-				SSLMode         string        `mapstructure:"SSLMODE"`
-				MaxConnLifetime time.Duration `mapstructure:"MAX_CONNECTION_LIFETIME"`
-				MaxIdleConn     int           `mapstructure:"MAX_IDLE_CONNECTION"`
-				MaxOpenConn     int           `mapstructure:"MAX_OPEN_CONNECTION"`
+			Routing struct {
+				ReadYourWritesWindow time.Duration `mapstructure:"READ_YOUR_WRITES_WINDOW"`
+				MaxReplicaLag        time.Duration `mapstructure:"MAX_REPLICA_LAG"`
+				LagCheckInterval     time.Duration `mapstructure:"LAG_CHECK_INTERVAL"`
 			}
 		} `mapstructure:"PG"`
 	}
 
+	Events struct {
+		Outbox Outbox
+	}
+
 	Server struct {
 		Env      string `mapstructure:"ENV"`
 		LogLevel string `mapstructure:"LOG_LEVEL"`
 		Port     string `mapstructure:"PORT"`
+		// TrustedProxies are the IPs and CIDRs of the load balancers or
+		// reverse proxies in front of the server. X-Forwarded-For and
+		// X-Real-IP are only believed from them; with none, the client IP
+		// is always the connection's address.
+		TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+		Log            struct {
+			Format string `mapstructure:"FORMAT"`
+			Output string `mapstructure:"OUTPUT"`
+			File   struct {
+				Path       string `mapstructure:"PATH"`
+				MaxSizeMB  int    `mapstructure:"MAX_SIZE_MB"`
+				MaxBackups int    `mapstructure:"MAX_BACKUPS"`
+				MaxAgeDays int    `mapstructure:"MAX_AGE_DAYS"`
+			}
+			Sampling struct {
+				TraceEvery uint32 `mapstructure:"TRACE_EVERY"`
+				DebugEvery uint32 `mapstructure:"DEBUG_EVERY"`
+				InfoEvery  uint32 `mapstructure:"INFO_EVERY"`
+			}
+		}
+		RateLimit RateLimit `mapstructure:"RATE_LIMIT"`
+		Metrics   struct {
+			Enable bool   `mapstructure:"ENABLE"`
+			Token  Secret `mapstructure:"TOKEN"`
+		}
 		Shutdown struct {
 			CleanupPeriodSeconds int64 `mapstructure:"CLEANUP_PERIOD_SECONDS"`
 			GracePeriodSeconds   int64 `mapstructure:"GRACE_PERIOD_SECONDS"`
+			// Timeout bounds the whole shutdown, grace and cleanup periods
+			// included.
+			Timeout time.Duration `mapstructure:"TIMEOUT"`
 		}
 	}
+
+	Jobs struct {
+		Enable   bool   `mapstructure:"ENABLE"`
+		Timezone string `mapstructure:"TIMEZONE"`
+	}
+
+	Notifications struct {
+		DefaultLocale string `mapstructure:"DEFAULT_LOCALE"`
+		SMTP          SMTP
+	}
+
+	Secrets struct {
+		Provider string `mapstructure:"PROVIDER"`
+		Dir      string `mapstructure:"DIR"`
+		Path     string `mapstructure:"PATH"`
+		Key      Secret `mapstructure:"KEY"`
+	}
+
+	Webhooks struct {
+		PollInterval   time.Duration `mapstructure:"POLL_INTERVAL"`
+		BatchSize      int           `mapstructure:"BATCH_SIZE"`
+		Timeout        time.Duration `mapstructure:"TIMEOUT"`
+		MaxAttempts    int           `mapstructure:"MAX_ATTEMPTS"`
+		InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+		MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+	}
+
+	Tracing struct {
+		Exporter     string  `mapstructure:"EXPORTER"`
+		OTLPEndpoint string  `mapstructure:"OTLP_ENDPOINT"`
+		SampleRatio  float64 `mapstructure:"SAMPLE_RATIO"`
+	}
 }
 
-var (
-	conf Config
-	once sync.Once
-)
+// PostgresPool holds the connection and pool settings of one Postgres pool.
+type PostgresPool struct {
+	Host            string        `mapstructure:"HOST"`
+	Port            string        `mapstructure:"PORT"`
+	User            string        `mapstructure:"USER"`
+	Password        Secret        `mapstructure:"PASSWORD"`
+	Name            string        `mapstructure:"NAME"`
+	SSLMode         string        `mapstructure:"SSLMODE"`
+	MaxConnLifetime time.Duration `mapstructure:"MAX_CONNECTION_LIFETIME"`
+	MaxIdleConn     int           `mapstructure:"MAX_IDLE_CONNECTION"`
+	MaxOpenConn     int           `mapstructure:"MAX_OPEN_CONNECTION"`
+}
 
-// Get are responsible to load env and get data an return the struct
-func Get() *Config {
-	viper.SetConfigFile(".env")
-	err := viper.ReadInConfig()
+// Outbox holds the settings of the outbox relay, which dispatches domain
+// events to their subscribers.
+type Outbox struct {
+	PollInterval   time.Duration `mapstructure:"POLL_INTERVAL"`
+	BatchSize      int           `mapstructure:"BATCH_SIZE"`
+	Lease          time.Duration `mapstructure:"LEASE"`
+	MaxAttempts    int           `mapstructure:"MAX_ATTEMPTS"`
+	InitialBackoff time.Duration `mapstructure:"INITIAL_BACKOFF"`
+	MaxBackoff     time.Duration `mapstructure:"MAX_BACKOFF"`
+}
+
+// SMTP holds the mail server used to send notification emails. Email is
+// disabled when Host is empty.
+type SMTP struct {
+	Host     string        `mapstructure:"HOST"`
+	Port     string        `mapstructure:"PORT"`
+	Username string        `mapstructure:"USERNAME"`
+	Password Secret        `mapstructure:"PASSWORD"`
+	From     string        `mapstructure:"FROM"`
+	Timeout  time.Duration `mapstructure:"TIMEOUT"`
+}
 
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed reading config file")
+// CORS holds the CORS headers sent with every response. It can be changed
+// without a restart.
+type CORS struct {
+	AllowCredentials bool     `mapstructure:"ALLOW_CREDENTIALS"`
+	AllowedHeaders   []string `mapstructure:"ALLOWED_HEADERS"`
+	AllowedMethods   []string `mapstructure:"ALLOWED_METHODS"`
+	AllowedOrigins   []string `mapstructure:"ALLOWED_ORIGINS"`
+	Enable           bool     `mapstructure:"ENABLE"`
+	MaxAgeSeconds    int      `mapstructure:"MAX_AGE_SECONDS"`
+}
+
+// RateLimit holds the default request rate allowed per client, the stricter
+// rate of authentication endpoints and the lockout after failed logins. It
+// can be changed without a restart.
+type RateLimit struct {
+	Enable            bool `mapstructure:"ENABLE"`
+	RequestsPerMinute int  `mapstructure:"REQUESTS_PER_MINUTE"`
+	Burst             int  `mapstructure:"BURST"`
+	Auth              struct {
+		RequestsPerMinute int `mapstructure:"REQUESTS_PER_MINUTE"`
+		Burst             int `mapstructure:"BURST"`
+	}
+	Lockout struct {
+		MaxFailures int           `mapstructure:"MAX_FAILURES"`
+		Duration    time.Duration `mapstructure:"DURATION"`
+		MaxDuration time.Duration `mapstructure:"MAX_DURATION"`
 	}
+}
+
+var (
+	conf    Config
+	confErr error
+	once    sync.Once
+)
 
+// Get loads the configuration once and returns it on every call. Values come
+// from environment variables, then the optional .env file, then defaults.
+// It returns an error listing every missing or invalid field.
+func Get() (*Config, error) {
 	once.Do(func() {
-		log.Info().Msg("Service configuration initialized.")
-		err = viper.Unmarshal(&conf)
-		if err != nil {
-			log.Fatal().Err(err).Msg("")
+		confErr = load(viper.GetViper(), ".env", &conf)
+		if confErr == nil {
+			log.Info().Msg("Service configuration initialized.")
 		}
 	})
-
-	return &conf
+	if confErr != nil {
+		return nil, confErr
+	}
+	return &conf, nil
 }
@@ -0,0 +1,144 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// defaults are applied to any key set neither in the environment nor in the
+// .env file. Keys without a default are required or may be left empty, as
+// checked by Validate.
+var defaults = map[string]interface{}{
+	"APP.NAME":                                   "sanika-farm-be",
+	"APP.CORS.ENABLE":                            false,
+	"APP.CORS.MAX_AGE_SECONDS":                   300,
+	"AUTH.TOKEN_TTL":                             "12h",
+	"AUTH.ADMIN_ROLE_ID":                         1,
+	"CACHE.REDIS.PRIMARY.PORT":                   "6379",
+	"DB.PG.READ.PORT":                            "5432",
+	"DB.PG.READ.SSLMODE":                         "require",
+	"DB.PG.READ.MAX_CONNECTION_LIFETIME":         "5m",
+	"DB.PG.READ.MAX_IDLE_CONNECTION":             10,
+	"DB.PG.READ.MAX_OPEN_CONNECTION":             10,
+	"DB.PG.WRITE.PORT":                           "5432",
+	"DB.PG.WRITE.SSLMODE":                        "require",
+	"DB.PG.WRITE.MAX_CONNECTION_LIFETIME":        "5m",
+	"DB.PG.WRITE.MAX_IDLE_CONNECTION":            10,
+	"DB.PG.WRITE.MAX_OPEN_CONNECTION":            10,
+	"DB.PG.CONNECT.MAX_WAIT":                     "60s",
+	"DB.PG.CONNECT.INITIAL_BACKOFF":              "500ms",
+	"DB.PG.CONNECT.MAX_BACKOFF":                  "10s",
+	"DB.PG.ROUTING.READ_YOUR_WRITES_WINDOW":      "5s",
+	"DB.PG.ROUTING.MAX_REPLICA_LAG":              "10s",
+	"DB.PG.ROUTING.LAG_CHECK_INTERVAL":           "5s",
+	"EVENTS.OUTBOX.POLL_INTERVAL":                "1s",
+	"EVENTS.OUTBOX.BATCH_SIZE":                   100,
+	"EVENTS.OUTBOX.LEASE":                        "1m",
+	"EVENTS.OUTBOX.MAX_ATTEMPTS":                 10,
+	"EVENTS.OUTBOX.INITIAL_BACKOFF":              "1s",
+	"EVENTS.OUTBOX.MAX_BACKOFF":                  "10m",
+	"JOBS.ENABLE":                                true,
+	"JOBS.TIMEZONE":                              "UTC",
+	"NOTIFICATIONS.DEFAULT_LOCALE":               "en",
+	"NOTIFICATIONS.SMTP.PORT":                    "587",
+	"NOTIFICATIONS.SMTP.TIMEOUT":                 "10s",
+	"SERVER.ENV":                                 "production",
+	"SERVER.LOG_LEVEL":                           "info",
+	"SERVER.PORT":                                "8080",
+	"SERVER.LOG.FORMAT":                          "console",
+	"SERVER.LOG.OUTPUT":                          "stdout",
+	"SERVER.LOG.FILE.MAX_SIZE_MB":                100,
+	"SERVER.LOG.FILE.MAX_BACKUPS":                7,
+	"SERVER.LOG.FILE.MAX_AGE_DAYS":               14,
+	"SERVER.METRICS.ENABLE":                      false,
+	"SERVER.RATE_LIMIT.ENABLE":                   false,
+	"SERVER.RATE_LIMIT.REQUESTS_PER_MINUTE":      60,
+	"SERVER.RATE_LIMIT.BURST":                    20,
+	"SERVER.RATE_LIMIT.AUTH.REQUESTS_PER_MINUTE": 10,
+	"SERVER.RATE_LIMIT.AUTH.BURST":               5,
+	"SERVER.RATE_LIMIT.LOCKOUT.MAX_FAILURES":     5,
+	"SERVER.RATE_LIMIT.LOCKOUT.DURATION":         "1m",
+	"SERVER.RATE_LIMIT.LOCKOUT.MAX_DURATION":     "1h",
+	"SERVER.SHUTDOWN.CLEANUP_PERIOD_SECONDS":     15,
+	"SERVER.SHUTDOWN.GRACE_PERIOD_SECONDS":       15,
+	"SERVER.SHUTDOWN.TIMEOUT":                    "45s",
+	"SECRETS.PROVIDER":                           "none",
+	"TRACING.EXPORTER":                           "none",
+	"WEBHOOKS.POLL_INTERVAL":                     "2s",
+	"WEBHOOKS.BATCH_SIZE":                        50,
+	"WEBHOOKS.TIMEOUT":                           "10s",
+	"WEBHOOKS.MAX_ATTEMPTS":                      12,
+	"WEBHOOKS.INITIAL_BACKOFF":                   "30s",
+	"WEBHOOKS.MAX_BACKOFF":                       "6h",
+	"TRACING.SAMPLE_RATIO":                       1,
+}
+
+// load reads the configuration into conf. Every field can be set through an
+// environment variable named after its key, either as is (DB.PG.READ.HOST)
+// or with underscores (DB_PG_READ_HOST), or read from the file named by the
+// same variable with a _FILE suffix. Secret fields may instead come from the
+// configured SecretProvider. The .env file at path is optional.
+func load(v *viper.Viper, path string, conf *Config) error {
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+	for _, key := range Keys() {
+		if err := v.BindEnv(key, strings.ReplaceAll(key, ".", "_"), key); err != nil {
+			return fmt.Errorf("binding %s: %w", key, err)
+		}
+	}
+
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		log.Info().Str("file", path).Msg("No config file found, using environment variables only.")
+	}
+
+	fromFile, err := resolveFileIndirection(v)
+	if err != nil {
+		return err
+	}
+	if err := resolveSecrets(v, fromFile); err != nil {
+		return err
+	}
+
+	if err := v.Unmarshal(conf); err != nil {
+		return fmt.Errorf("decoding configuration: %w", err)
+	}
+	return conf.Validate()
+}
+
+// Keys returns the key of every field in Config, such as DB.PG.READ.HOST.
+func Keys() []string {
+	var keys []string
+	collectKeys(reflect.TypeOf(Config{}), "", func(key string, _ reflect.Type) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// collectKeys calls visit with the key and type of every leaf field of t.
+func collectKeys(t reflect.Type, prefix string, visit func(key string, t reflect.Type)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = strings.ToUpper(field.Name)
+		}
+		key := prefix + name
+
+		if field.Type.Kind() == reflect.Struct {
+			collectKeys(field.Type, key+".", visit)
+			continue
+		}
+		visit(key, field.Type)
+	}
+}
@@ -0,0 +1,154 @@
+package configs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var testTokenSecret = strings.Repeat("s", minTokenSecretLength)
+
+// setRequiredEnv sets the keys that have no default and must be set.
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	for key, value := range map[string]string{
+		"AUTH.TOKEN_SECRET": testTokenSecret,
+		"DB.PG.READ.HOST":   "replica.db",
+		"DB.PG.READ.USER":   "farm",
+		"DB.PG.READ.NAME":   "farm",
+		"DB.PG.WRITE.HOST":  "primary.db",
+		"DB.PG.WRITE.USER":  "farm",
+		"DB.PG.WRITE.NAME":  "farm",
+	} {
+		t.Setenv(key, value)
+	}
+}
+
+// loadTest loads the configuration with the given .env file contents, or
+// without a .env file when envFile is empty.
+func loadTest(t *testing.T, envFile string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if envFile != "" {
+		if err := os.WriteFile(path, []byte(envFile), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var conf Config
+	err := load(viper.New(), path, &conf)
+	return &conf, err
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setRequiredEnv(t)
+	conf, err := loadTest(t, "")
+	if err != nil {
+		t.Fatalf("load without a .env file: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		got  interface{}
+		want interface{}
+	}{
+		{"APP.NAME", conf.App.Name, "sanika-farm-be"},
+		{"AUTH.TOKEN_TTL", conf.Auth.TokenTTL, 12 * time.Hour},
+		{"AUTH.ADMIN_ROLE_ID", conf.Auth.AdminRoleID, 1},
+		{"DB.PG.READ.PORT", conf.DB.Postgres.Read.Port, "5432"},
+		{"DB.PG.WRITE.SSLMODE", conf.DB.Postgres.Write.SSLMode, "require"},
+		{"DB.PG.WRITE.MAX_CONNECTION_LIFETIME", conf.DB.Postgres.Write.MaxConnLifetime, 5 * time.Minute},
+		{"JOBS.TIMEZONE", conf.Jobs.Timezone, "UTC"},
+		{"SERVER.ENV", conf.Server.Env, "production"},
+		{"SERVER.PORT", conf.Server.Port, "8080"},
+		{"SERVER.RATE_LIMIT.ENABLE", conf.Server.RateLimit.Enable, false},
+		{"SERVER.RATE_LIMIT.LOCKOUT.DURATION", conf.Server.RateLimit.Lockout.Duration, time.Minute},
+		{"SERVER.TRUSTED_PROXIES", len(conf.Server.TrustedProxies), 0},
+		{"TRACING.SAMPLE_RATIO", conf.Tracing.SampleRatio, 1.0},
+		{"WEBHOOKS.MAX_BACKOFF", conf.Webhooks.MaxBackoff, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.key, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   string
+		value string
+		got   func(c *Config) interface{}
+		want  interface{}
+	}{
+		{"dotted name", "SERVER.PORT", "9090", func(c *Config) interface{} { return c.Server.Port }, "9090"},
+		{"underscored name", "SERVER_PORT", "9090", func(c *Config) interface{} { return c.Server.Port }, "9090"},
+		{"duration", "AUTH_TOKEN_TTL", "30m", func(c *Config) interface{} { return c.Auth.TokenTTL }, 30 * time.Minute},
+		{"bool", "SERVER.RATE_LIMIT.ENABLE", "true", func(c *Config) interface{} { return c.Server.RateLimit.Enable }, true},
+		{"list", "SERVER_TRUSTED_PROXIES", "10.0.0.0/8,192.0.2.1", func(c *Config) interface{} { return c.Server.TrustedProxies },
+			[]string{"10.0.0.0/8", "192.0.2.1"}},
+		{"secret", "CACHE.REDIS.PRIMARY.PASSWORD", "hunter2", func(c *Config) interface{} { return c.Cache.Redis.Primary.Password.Value() }, "hunter2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			t.Setenv(tt.env, tt.value)
+			conf, err := loadTest(t, "")
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if got := tt.got(conf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s=%s loaded as %v, want %v", tt.env, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SERVER_PORT", "6060")
+	conf, err := loadTest(t, strings.Join([]string{
+		"SERVER.PORT=7070",
+		"SERVER.LOG_LEVEL=warn",
+		"JOBS.TIMEZONE=Asia/Jakarta",
+	}, "\n"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if conf.Server.Port != "6060" {
+		t.Errorf("SERVER.PORT = %s, want the environment's 6060 over the file's", conf.Server.Port)
+	}
+	if conf.Server.LogLevel != "warn" || conf.Jobs.Timezone != "Asia/Jakarta" {
+		t.Errorf("LOG_LEVEL = %s and TIMEZONE = %s, want the file's warn and Asia/Jakarta", conf.Server.LogLevel, conf.Jobs.Timezone)
+	}
+	if conf.Server.Env != "production" {
+		t.Errorf("SERVER.ENV = %s, want the default production", conf.Server.Env)
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	t.Setenv("SERVER.PORT", "http")
+	_, err := loadTest(t, "")
+
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("load = %v, want a *ValidationError", err)
+	}
+	for _, want := range []string{
+		"SERVER.PORT must be a port number",
+		"AUTH.TOKEN_SECRET must be at least",
+		"DB.PG.READ.HOST is required",
+		"DB.PG.WRITE.USER is required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("load error %q does not mention %q", err, want)
+		}
+	}
+}
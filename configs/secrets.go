@@ -0,0 +1,241 @@
+package configs
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const redactedSecret = "[REDACTED]"
+
+// Secret is a configuration value that must never be printed. Formatting or
+// marshalling it yields a placeholder; use Value to read it.
+type Secret string
+
+// Value returns the secret in plain text.
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String masks the secret.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redactedSecret
+}
+
+// GoString masks the secret in %#v output.
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// MarshalJSON masks the secret.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// SecretProvider looks up secrets by configuration key, e.g. DB.PG.WRITE.PASSWORD.
+type SecretProvider interface {
+	// Secret returns the secret stored under key, and false if there is none.
+	Secret(key string) (string, bool, error)
+}
+
+// FileSecretProvider reads each secret from its own file in Dir, named after
+// the key in lower case with underscores, e.g. db_pg_write_password. This is
+// the layout of Docker and Kubernetes secret mounts.
+type FileSecretProvider struct {
+	Dir string
+}
+
+// Secret reads the secret file for key.
+func (p FileSecretProvider) Secret(key string) (string, bool, error) {
+	name := strings.ToLower(strings.ReplaceAll(key, ".", "_"))
+	value, err := readSecretFile(filepath.Join(p.Dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// EncryptedFileSecretProvider reads secrets from a local file holding a JSON
+// object of key to secret, encrypted with AES-256-GCM. Use EncryptSecrets to
+// create the file.
+type EncryptedFileSecretProvider struct {
+	secrets map[string]string
+}
+
+// NewEncryptedFileSecretProvider decrypts the file at path with key, which
+// must be 32 bytes long.
+func NewEncryptedFileSecretProvider(path string, key []byte) (*EncryptedFileSecretProvider, error) {
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading secrets file: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("secrets file is too short")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("decrypting secrets file: wrong key or corrupted file")
+	}
+
+	p := &EncryptedFileSecretProvider{}
+	if err := json.Unmarshal(plain, &p.secrets); err != nil {
+		return nil, fmt.Errorf("decoding secrets file: %w", err)
+	}
+	return p, nil
+}
+
+// Secret returns the secret stored under key.
+func (p *EncryptedFileSecretProvider) Secret(key string) (string, bool, error) {
+	value, ok := p.secrets[key]
+	return value, ok, nil
+}
+
+// EncryptSecrets encrypts secrets for EncryptedFileSecretProvider with key,
+// which must be 32 bytes long.
+func EncryptSecrets(secrets map[string]string, key []byte) ([]byte, error) {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("secrets key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// DecodeSecretsKey decodes a base64 secrets key, as set in SECRETS.KEY.
+func DecodeSecretsKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("SECRETS.KEY is not valid base64: %w", err)
+	}
+	return key, nil
+}
+
+func readSecretFile(path string) (string, error) {
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(value), "\r\n"), nil
+}
+
+// SecretKeys returns the key of every Secret field in Config.
+func SecretKeys() []string {
+	var keys []string
+	secretType := reflect.TypeOf(Secret(""))
+	collectKeys(reflect.TypeOf(Config{}), "", func(key string, t reflect.Type) {
+		if t == secretType {
+			keys = append(keys, key)
+		}
+	})
+	return keys
+}
+
+// resolveFileIndirection sets every key whose environment variable is given
+// with a _FILE suffix, e.g. DB_PG_WRITE_PASSWORD_FILE, to the content of
+// that file. It returns the keys it set.
+func resolveFileIndirection(v *viper.Viper) (map[string]bool, error) {
+	resolved := make(map[string]bool)
+	for _, key := range Keys() {
+		for _, name := range []string{strings.ReplaceAll(key, ".", "_") + "_FILE", key + "_FILE"} {
+			path, ok := os.LookupEnv(name)
+			if !ok || path == "" {
+				continue
+			}
+			value, err := readSecretFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", name, err)
+			}
+			v.Set(key, value)
+			resolved[key] = true
+			break
+		}
+	}
+	return resolved, nil
+}
+
+// resolveSecrets fills the secret fields from the configured SecretProvider.
+// Secrets given through a _FILE variable take precedence.
+func resolveSecrets(v *viper.Viper, fromFile map[string]bool) error {
+	provider, err := newSecretProvider(v)
+	if err != nil || provider == nil {
+		return err
+	}
+
+	for _, key := range SecretKeys() {
+		if fromFile[key] || key == "SECRETS.KEY" {
+			continue
+		}
+		value, ok, err := provider.Secret(key)
+		if err != nil {
+			return fmt.Errorf("loading secret %s: %w", key, err)
+		}
+		if ok {
+			v.Set(key, value)
+		}
+	}
+	return nil
+}
+
+func newSecretProvider(v *viper.Viper) (SecretProvider, error) {
+	switch strings.ToLower(v.GetString("SECRETS.PROVIDER")) {
+	case "", "none":
+		return nil, nil
+	case "file":
+		dir := v.GetString("SECRETS.DIR")
+		if dir == "" {
+			return nil, errors.New("SECRETS.DIR is required for the file secret provider")
+		}
+		return FileSecretProvider{Dir: dir}, nil
+	case "encrypted":
+		path := v.GetString("SECRETS.PATH")
+		if path == "" {
+			return nil, errors.New("SECRETS.PATH is required for the encrypted secret provider")
+		}
+		key, err := DecodeSecretsKey(v.GetString("SECRETS.KEY"))
+		if err != nil {
+			return nil, err
+		}
+		return NewEncryptedFileSecretProvider(path, key)
+	default:
+		return nil, fmt.Errorf("SECRETS.PROVIDER must be one of none, file, encrypted, got %q", v.GetString("SECRETS.PROVIDER"))
+	}
+}
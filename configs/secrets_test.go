@@ -0,0 +1,171 @@
+package configs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+var testSecretsKey = bytes.Repeat([]byte{7}, 32)
+
+// sealSecrets writes secrets encrypted with key to a file and returns its
+// path.
+func sealSecrets(t *testing.T, secrets map[string]string, key []byte) string {
+	t.Helper()
+	sealed, err := EncryptSecrets(secrets, key)
+	if err != nil {
+		t.Fatalf("EncryptSecrets: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	if err := os.WriteFile(path, sealed, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadEncryptedSecrets(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SECRETS.PROVIDER", "encrypted")
+	t.Setenv("SECRETS.PATH", sealSecrets(t, map[string]string{
+		"DB.PG.WRITE.PASSWORD": "hunter2",
+		"DB.PG.READ.PASSWORD":  "from the sealed file",
+	}, testSecretsKey))
+	t.Setenv("SECRETS.KEY", base64.StdEncoding.EncodeToString(testSecretsKey))
+	t.Setenv("DB_PG_READ_PASSWORD_FILE", writeFile(t, "read_password", "from a _FILE\n"))
+
+	conf, err := loadTest(t, "")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := conf.DB.Postgres.Write.Password.Value(); got != "hunter2" {
+		t.Errorf("DB.PG.WRITE.PASSWORD = %q, want the sealed hunter2", got)
+	}
+	if got := conf.DB.Postgres.Read.Password.Value(); got != "from a _FILE" {
+		t.Errorf("DB.PG.READ.PASSWORD = %q, want the _FILE to take precedence", got)
+	}
+}
+
+func TestEncryptedFileSecretProviderRejects(t *testing.T) {
+	path := sealSecrets(t, map[string]string{"DB.PG.WRITE.PASSWORD": "hunter2"}, testSecretsKey)
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 1
+
+	tests := []struct {
+		name string
+		path string
+		key  []byte
+		want string
+	}{
+		{"wrong key", path, bytes.Repeat([]byte{8}, 32), "wrong key or corrupted file"},
+		{"tampered ciphertext", writeFile(t, "tampered.enc", string(tampered)), testSecretsKey, "wrong key or corrupted file"},
+		{"truncated file", writeFile(t, "truncated.enc", string(sealed[:4])), testSecretsKey, "too short"},
+		{"short key", path, testSecretsKey[:16], "must be 32 bytes"},
+		{"missing file", filepath.Join(t.TempDir(), "missing.enc"), testSecretsKey, "reading secrets file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEncryptedFileSecretProvider(tt.path, tt.key)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewEncryptedFileSecretProvider = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretFilesAreTrimmed(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"newline", "hunter2\n", "hunter2"},
+		{"CRLF", "hunter2\r\n", "hunter2"},
+		{"several newlines", "hunter2\n\n", "hunter2"},
+		{"inner spaces kept", " hunter 2 \n", " hunter 2 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			t.Setenv("DB_PG_WRITE_PASSWORD_FILE", writeFile(t, "password", tt.content))
+			t.Setenv("SECRETS.PROVIDER", "file")
+			dir := t.TempDir()
+			t.Setenv("SECRETS.DIR", dir)
+			if err := os.WriteFile(filepath.Join(dir, "cache_redis_primary_password"), []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			conf, err := loadTest(t, "")
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if got := conf.DB.Postgres.Write.Password.Value(); got != tt.want {
+				t.Errorf("password from a _FILE = %q, want %q", got, tt.want)
+			}
+			if got := conf.Cache.Redis.Primary.Password.Value(); got != tt.want {
+				t.Errorf("password from the secrets directory = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretsAreMasked(t *testing.T) {
+	var c Config
+	c.Auth.TokenSecret = "hunter2-token"
+	c.DB.Postgres.Write.Password = "hunter2"
+
+	var logged bytes.Buffer
+	logger := zerolog.New(&logged)
+	logger.Info().
+		Interface("config", c).
+		Stringer("password", c.DB.Postgres.Write.Password).
+		Interface("secret", c.Auth.TokenSecret).
+		Msg("")
+	marshalled, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := map[string]string{
+		"%v":      fmt.Sprintf("%v", c),
+		"%+v":     fmt.Sprintf("%+v", c),
+		"%#v":     fmt.Sprintf("%#v", c),
+		"%s":      fmt.Sprintf("%s", c.Auth.TokenSecret),
+		"JSON":    string(marshalled),
+		"zerolog": logged.String(),
+	}
+	for name, out := range outputs {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("%s output leaks the secret: %s", name, out)
+		}
+		if !strings.Contains(out, redactedSecret) {
+			t.Errorf("%s output does not show the placeholder: %s", name, out)
+		}
+	}
+
+	if s := Secret("").String(); s != "" {
+		t.Errorf("empty secret = %q, want empty so it reads as unset", s)
+	}
+	if v := c.DB.Postgres.Write.Password.Value(); v != "hunter2" {
+		t.Errorf("Value = %q, want the plain secret", v)
+	}
+}
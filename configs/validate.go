@@ -0,0 +1,231 @@
+package configs
+
+import (
+	"fmt"
+	"net/mail"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minTokenSecretLength is the shortest AUTH.TOKEN_SECRET accepted, in bytes,
+// the size of the HMAC-SHA256 key.
+const minTokenSecretLength = 32
+
+// Environments are the accepted values of Server.Env.
+var Environments = []string{"development", "staging", "production"}
+
+// ValidationError lists every problem found in a configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s is required", key)
+	}
+}
+
+func (v *validator) port(key, value string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		v.addf("%s must be a port number between 1 and 65535, got %q", key, value)
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return
+		}
+	}
+	v.addf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) postgresPool(prefix string, pool PostgresPool) {
+	v.required(prefix+".HOST", pool.Host)
+	v.port(prefix+".PORT", pool.Port)
+	v.required(prefix+".USER", pool.User)
+	v.required(prefix+".NAME", pool.Name)
+	v.oneOf(prefix+".SSLMODE", pool.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	if pool.MaxOpenConn <= 0 {
+		v.addf("%s.MAX_OPEN_CONNECTION must be greater than 0", prefix)
+	}
+	if pool.MaxIdleConn <= 0 {
+		v.addf("%s.MAX_IDLE_CONNECTION must be greater than 0", prefix)
+	} else if pool.MaxOpenConn > 0 && pool.MaxIdleConn > pool.MaxOpenConn {
+		v.addf("%s.MAX_IDLE_CONNECTION must not exceed MAX_OPEN_CONNECTION", prefix)
+	}
+	if pool.MaxConnLifetime < 0 {
+		v.addf("%s.MAX_CONNECTION_LIFETIME must not be negative", prefix)
+	}
+}
+
+// Validate checks the configuration and reports all problems at once.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.port("SERVER.PORT", c.Server.Port)
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			if _, err := netip.ParseAddr(proxy); err != nil {
+				v.addf("SERVER.TRUSTED_PROXIES entry %q must be an IP address or CIDR", proxy)
+			}
+		}
+	}
+	v.oneOf("SERVER.ENV", c.Server.Env, Environments...)
+	v.oneOf("SERVER.LOG.FORMAT", c.Server.Log.Format, "console", "json")
+	v.oneOf("SERVER.LOG.OUTPUT", c.Server.Log.Output, "stdout", "file")
+	if strings.EqualFold(c.Server.Log.Output, "file") {
+		v.required("SERVER.LOG.FILE.PATH", c.Server.Log.File.Path)
+	}
+	if c.Server.Shutdown.CleanupPeriodSeconds < 0 {
+		v.addf("SERVER.SHUTDOWN.CLEANUP_PERIOD_SECONDS must not be negative")
+	}
+	if c.Server.Shutdown.GracePeriodSeconds < 0 {
+		v.addf("SERVER.SHUTDOWN.GRACE_PERIOD_SECONDS must not be negative")
+	}
+	if c.Server.Shutdown.Timeout <= 0 {
+		v.addf("SERVER.SHUTDOWN.TIMEOUT must be greater than 0")
+	}
+
+	if rateLimit := c.Server.RateLimit; rateLimit.Enable {
+		if rateLimit.RequestsPerMinute <= 0 {
+			v.addf("SERVER.RATE_LIMIT.REQUESTS_PER_MINUTE must be greater than 0")
+		}
+		if rateLimit.Burst <= 0 {
+			v.addf("SERVER.RATE_LIMIT.BURST must be greater than 0")
+		}
+		if rateLimit.Auth.RequestsPerMinute <= 0 {
+			v.addf("SERVER.RATE_LIMIT.AUTH.REQUESTS_PER_MINUTE must be greater than 0")
+		}
+		if rateLimit.Auth.Burst <= 0 {
+			v.addf("SERVER.RATE_LIMIT.AUTH.BURST must be greater than 0")
+		}
+		if rateLimit.Lockout.MaxFailures <= 0 {
+			v.addf("SERVER.RATE_LIMIT.LOCKOUT.MAX_FAILURES must be greater than 0")
+		}
+		if rateLimit.Lockout.Duration <= 0 {
+			v.addf("SERVER.RATE_LIMIT.LOCKOUT.DURATION must be greater than 0")
+		}
+		if rateLimit.Lockout.MaxDuration < rateLimit.Lockout.Duration {
+			v.addf("SERVER.RATE_LIMIT.LOCKOUT.MAX_DURATION must not be less than DURATION")
+		}
+	}
+
+	if len(c.Auth.TokenSecret.Value()) < minTokenSecretLength {
+		v.addf("AUTH.TOKEN_SECRET must be at least %d bytes", minTokenSecretLength)
+	}
+	if c.Auth.TokenTTL <= 0 {
+		v.addf("AUTH.TOKEN_TTL must be greater than 0")
+	}
+	if c.Auth.AdminRoleID <= 0 {
+		v.addf("AUTH.ADMIN_ROLE_ID must be greater than 0")
+	}
+
+	v.postgresPool("DB.PG.READ", c.DB.Postgres.Read)
+	v.postgresPool("DB.PG.WRITE", c.DB.Postgres.Write)
+	if c.DB.Postgres.Connect.MaxWait < 0 {
+		v.addf("DB.PG.CONNECT.MAX_WAIT must not be negative")
+	}
+	if c.DB.Postgres.Connect.InitialBackoff <= 0 {
+		v.addf("DB.PG.CONNECT.INITIAL_BACKOFF must be greater than 0")
+	}
+	if c.DB.Postgres.Connect.MaxBackoff < c.DB.Postgres.Connect.InitialBackoff {
+		v.addf("DB.PG.CONNECT.MAX_BACKOFF must not be less than INITIAL_BACKOFF")
+	}
+	if c.DB.Postgres.Routing.ReadYourWritesWindow < 0 {
+		v.addf("DB.PG.ROUTING.READ_YOUR_WRITES_WINDOW must not be negative")
+	}
+	if c.DB.Postgres.Routing.MaxReplicaLag <= 0 {
+		v.addf("DB.PG.ROUTING.MAX_REPLICA_LAG must be greater than 0")
+	}
+	if c.DB.Postgres.Routing.LagCheckInterval <= 0 {
+		v.addf("DB.PG.ROUTING.LAG_CHECK_INTERVAL must be greater than 0")
+	}
+
+	outbox := c.Events.Outbox
+	if outbox.PollInterval <= 0 {
+		v.addf("EVENTS.OUTBOX.POLL_INTERVAL must be greater than 0")
+	}
+	if outbox.BatchSize <= 0 {
+		v.addf("EVENTS.OUTBOX.BATCH_SIZE must be greater than 0")
+	}
+	if outbox.Lease <= 0 {
+		v.addf("EVENTS.OUTBOX.LEASE must be greater than 0")
+	}
+	if outbox.MaxAttempts <= 0 {
+		v.addf("EVENTS.OUTBOX.MAX_ATTEMPTS must be greater than 0")
+	}
+	if outbox.InitialBackoff <= 0 {
+		v.addf("EVENTS.OUTBOX.INITIAL_BACKOFF must be greater than 0")
+	}
+	if outbox.MaxBackoff < outbox.InitialBackoff {
+		v.addf("EVENTS.OUTBOX.MAX_BACKOFF must not be less than INITIAL_BACKOFF")
+	}
+
+	if c.Cache.Redis.Primary.Host != "" {
+		v.port("CACHE.REDIS.PRIMARY.PORT", c.Cache.Redis.Primary.Port)
+	}
+
+	if _, err := time.LoadLocation(c.Jobs.Timezone); err != nil {
+		v.addf("JOBS.TIMEZONE is not a known time zone: %v", err)
+	}
+
+	v.required("NOTIFICATIONS.DEFAULT_LOCALE", c.Notifications.DefaultLocale)
+	if smtp := c.Notifications.SMTP; smtp.Host != "" {
+		v.port("NOTIFICATIONS.SMTP.PORT", smtp.Port)
+		if _, err := mail.ParseAddress(smtp.From); err != nil {
+			v.addf("NOTIFICATIONS.SMTP.FROM must be an email address, got %q", smtp.From)
+		}
+		if smtp.Timeout <= 0 {
+			v.addf("NOTIFICATIONS.SMTP.TIMEOUT must be greater than 0")
+		}
+	}
+
+	v.oneOf("TRACING.EXPORTER", c.Tracing.Exporter, "otlp", "stdout", "none")
+	if strings.EqualFold(c.Tracing.Exporter, "otlp") {
+		v.required("TRACING.OTLP_ENDPOINT", c.Tracing.OTLPEndpoint)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.addf("TRACING.SAMPLE_RATIO must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+	}
+
+	webhooks := c.Webhooks
+	if webhooks.PollInterval <= 0 {
+		v.addf("WEBHOOKS.POLL_INTERVAL must be greater than 0")
+	}
+	if webhooks.BatchSize <= 0 {
+		v.addf("WEBHOOKS.BATCH_SIZE must be greater than 0")
+	}
+	if webhooks.Timeout <= 0 {
+		v.addf("WEBHOOKS.TIMEOUT must be greater than 0")
+	}
+	if webhooks.MaxAttempts <= 0 {
+		v.addf("WEBHOOKS.MAX_ATTEMPTS must be greater than 0")
+	}
+	if webhooks.InitialBackoff <= 0 {
+		v.addf("WEBHOOKS.INITIAL_BACKOFF must be greater than 0")
+	}
+	if webhooks.MaxBackoff < webhooks.InitialBackoff {
+		v.addf("WEBHOOKS.MAX_BACKOFF must not be less than INITIAL_BACKOFF")
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
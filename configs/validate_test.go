@@ -0,0 +1,100 @@
+package configs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validConfig returns the defaults with the required keys set.
+func validConfig(t *testing.T) Config {
+	t.Helper()
+	setRequiredEnv(t)
+	conf, err := loadTest(t, "")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	return *conf
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		want   string
+	}{
+		{"valid", func(*Config) {}, ""},
+		{"valid with rate limits and SMTP", func(c *Config) {
+			c.Server.RateLimit.Enable = true
+			c.Notifications.SMTP.Host = "smtp.example.com"
+			c.Notifications.SMTP.From = "farm@example.com"
+			c.Server.TrustedProxies = []string{"10.0.0.0/8", "192.0.2.1"}
+		}, ""},
+		{"port", func(c *Config) { c.Server.Port = "70000" }, "SERVER.PORT must be a port number between 1 and 65535"},
+		{"environment", func(c *Config) { c.Server.Env = "prod" }, "SERVER.ENV must be one of development, staging, production"},
+		{"log file", func(c *Config) { c.Server.Log.Output = "file" }, "SERVER.LOG.FILE.PATH is required"},
+		{"trusted proxy", func(c *Config) { c.Server.TrustedProxies = []string{"proxy.internal"} }, `SERVER.TRUSTED_PROXIES entry "proxy.internal"`},
+		{"rate limit", func(c *Config) {
+			c.Server.RateLimit.Enable = true
+			c.Server.RateLimit.Lockout.MaxDuration = time.Second
+		}, "SERVER.RATE_LIMIT.LOCKOUT.MAX_DURATION must not be less than DURATION"},
+		{"token secret", func(c *Config) { c.Auth.TokenSecret = "short" }, "AUTH.TOKEN_SECRET must be at least 32 bytes"},
+		{"idle connections", func(c *Config) { c.DB.Postgres.Read.MaxIdleConn = 20 }, "DB.PG.READ.MAX_IDLE_CONNECTION must not exceed MAX_OPEN_CONNECTION"},
+		{"ssl mode", func(c *Config) { c.DB.Postgres.Write.SSLMode = "always" }, "DB.PG.WRITE.SSLMODE must be one of"},
+		{"backoff", func(c *Config) { c.Events.Outbox.MaxBackoff = time.Millisecond }, "EVENTS.OUTBOX.MAX_BACKOFF must not be less than INITIAL_BACKOFF"},
+		{"time zone", func(c *Config) { c.Jobs.Timezone = "Mars/Olympus" }, "JOBS.TIMEZONE is not a known time zone"},
+		{"SMTP sender", func(c *Config) {
+			c.Notifications.SMTP.Host = "smtp.example.com"
+			c.Notifications.SMTP.From = "farm"
+		}, "NOTIFICATIONS.SMTP.FROM must be an email address"},
+		{"tracing endpoint", func(c *Config) { c.Tracing.Exporter = "otlp" }, "TRACING.OTLP_ENDPOINT is required"},
+		{"sample ratio", func(c *Config) { c.Tracing.SampleRatio = 2 }, "TRACING.SAMPLE_RATIO must be between 0 and 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig(t)
+			tt.change(&c)
+			err := c.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Validate = %v, want a *ValidationError", err)
+			}
+			if len(invalid.Problems) != 1 || !strings.HasPrefix(invalid.Problems[0], tt.want) {
+				t.Errorf("problems = %q, want one starting with %q", invalid.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAggregatesProblems(t *testing.T) {
+	c := validConfig(t)
+	c.Server.Port = ""
+	c.Auth.TokenTTL = 0
+	c.DB.Postgres.Write.Host = ""
+	c.Webhooks.BatchSize = 0
+
+	var invalid *ValidationError
+	if err := c.Validate(); !errors.As(err, &invalid) {
+		t.Fatalf("Validate = %v, want a *ValidationError", err)
+	}
+	want := []string{
+		`SERVER.PORT must be a port number between 1 and 65535, got ""`,
+		"AUTH.TOKEN_TTL must be greater than 0",
+		"DB.PG.WRITE.HOST is required",
+		"WEBHOOKS.BATCH_SIZE must be greater than 0",
+	}
+	if strings.Join(invalid.Problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems = %q, want %q", invalid.Problems, want)
+	}
+	if msg := invalid.Error(); msg != "invalid configuration: "+strings.Join(want, "; ") {
+		t.Errorf("Error() = %q", msg)
+	}
+}
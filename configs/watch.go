@@ -0,0 +1,178 @@
+package configs
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// hotReloadable are the keys, or key prefixes ending in a dot, that can
+// change without a restart. A change to any other key is logged as
+// requiring a restart and otherwise ignored.
+var hotReloadable = []string{
+	"SERVER.LOG_LEVEL",
+	"APP.CORS.",
+	"SERVER.RATE_LIMIT.",
+}
+
+// LogLevelChanged is published when SERVER.LOG_LEVEL changes.
+type LogLevelChanged struct {
+	Level string
+}
+
+// CORSChanged is published when any APP.CORS setting changes.
+type CORSChanged struct {
+	CORS CORS
+}
+
+// RateLimitChanged is published when any SERVER.RATE_LIMIT setting changes.
+type RateLimitChanged struct {
+	RateLimit RateLimit
+}
+
+// Watcher watches the config file and publishes changes of the settings
+// that can be changed without a restart. Config values returned by Get are
+// never modified; subscribers apply the changes they receive.
+type Watcher struct {
+	mu        sync.Mutex
+	current   Config
+	logLevel  []func(LogLevelChanged)
+	cors      []func(CORSChanged)
+	rateLimit []func(RateLimitChanged)
+}
+
+var (
+	watcher     *Watcher
+	watcherOnce sync.Once
+)
+
+// Watch starts watching the config file for changes from config. The first
+// call starts watching; later calls return the same Watcher.
+func Watch(config *Config) *Watcher {
+	watcherOnce.Do(func() {
+		watcher = &Watcher{current: *config}
+		if viper.ConfigFileUsed() == "" {
+			return
+		}
+		viper.OnConfigChange(func(fsnotify.Event) { watcher.reload(viper.GetViper()) })
+		viper.WatchConfig()
+		log.Info().Str("file", viper.ConfigFileUsed()).Msg("Watching config file for changes.")
+	})
+	return watcher
+}
+
+// OnLogLevelChanged subscribes fn to changes of the log level.
+func (w *Watcher) OnLogLevelChanged(fn func(LogLevelChanged)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.logLevel = append(w.logLevel, fn)
+}
+
+// OnCORSChanged subscribes fn to changes of the CORS settings.
+func (w *Watcher) OnCORSChanged(fn func(CORSChanged)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cors = append(w.cors, fn)
+}
+
+// OnRateLimitChanged subscribes fn to changes of the rate limit settings.
+func (w *Watcher) OnRateLimitChanged(fn func(RateLimitChanged)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rateLimit = append(w.rateLimit, fn)
+}
+
+func (w *Watcher) reload(v *viper.Viper) {
+	var next Config
+	if err := v.Unmarshal(&next); err != nil {
+		log.Error().Err(err).Msg("Failed decoding changed configuration, keeping the current one.")
+		return
+	}
+	if err := next.Validate(); err != nil {
+		log.Error().Err(err).Msg("Changed configuration is invalid, keeping the current one.")
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var restartRequired []string
+	changed := make(map[string]bool)
+	for _, key := range diffKeys(w.current, next) {
+		prefix := hotReloadablePrefix(key)
+		if prefix == "" {
+			restartRequired = append(restartRequired, key)
+			continue
+		}
+		changed[prefix] = true
+	}
+	if len(restartRequired) > 0 {
+		log.Warn().Strs("keys", restartRequired).Msg("Configuration changed, restart required to apply.")
+	}
+
+	if changed["SERVER.LOG_LEVEL"] {
+		w.current.Server.LogLevel = next.Server.LogLevel
+		for _, fn := range w.logLevel {
+			fn(LogLevelChanged{Level: next.Server.LogLevel})
+		}
+	}
+	if changed["APP.CORS."] {
+		w.current.App.CORS = next.App.CORS
+		for _, fn := range w.cors {
+			fn(CORSChanged{CORS: next.App.CORS})
+		}
+	}
+	if changed["SERVER.RATE_LIMIT."] {
+		w.current.Server.RateLimit = next.Server.RateLimit
+		for _, fn := range w.rateLimit {
+			fn(RateLimitChanged{RateLimit: next.Server.RateLimit})
+		}
+	}
+}
+
+func hotReloadablePrefix(key string) string {
+	for _, prefix := range hotReloadable {
+		if key == prefix || (strings.HasSuffix(prefix, ".") && strings.HasPrefix(key, prefix)) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// diffKeys returns the keys whose values differ between a and b.
+func diffKeys(a, b Config) []string {
+	before, after := flatten(reflect.ValueOf(a), ""), flatten(reflect.ValueOf(b), "")
+	var keys []string
+	for _, key := range Keys() {
+		if !reflect.DeepEqual(before[key], after[key]) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func flatten(v reflect.Value, prefix string) map[string]interface{} {
+	values := make(map[string]interface{})
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = strings.ToUpper(field.Name)
+		}
+		key := prefix + name
+
+		if field.Type.Kind() == reflect.Struct {
+			for k, value := range flatten(v.Field(i), key+".") {
+				values[k] = value
+			}
+			continue
+		}
+		values[key] = v.Field(i).Interface()
+	}
+	return values
+}
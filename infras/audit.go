@@ -0,0 +1,101 @@
+package infras
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+// AuditAction is the kind of change recorded in the audit trail.
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+// AuditChange is one change to an entity. Before is nil for creations and
+// After is nil for deletions. Both are stored as JSON, so they must not hold
+// secrets such as password hashes.
+type AuditChange struct {
+	Action     AuditAction
+	EntityType string
+	EntityID   interface{}
+	Before     interface{}
+	After      interface{}
+}
+
+var recordAudit = struct {
+	Query string
+}{
+	Query: `INSERT INTO audit_log (actor_user_id, action, entity_type, entity_id, before, after, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the ID of the user making the
+// request, recorded as the actor of audited changes.
+func WithActor(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the user ID carried by ctx, or nil for changes made
+// without an authenticated user.
+func ActorFromContext(ctx context.Context) *int {
+	userID, ok := ctx.Value(actorKey{}).(int)
+	if !ok {
+		return nil
+	}
+	return &userID
+}
+
+// RecordAudit writes changes to the audit trail in tx, so the audit rows
+// commit or roll back together with the changes themselves. The actor and
+// request ID are taken from ctx.
+func RecordAudit(ctx context.Context, tx *Tx, changes ...AuditChange) error {
+	var requestID *string
+	if id := logger.RequestID(ctx); id != "" {
+		requestID = &id
+	}
+
+	for _, change := range changes {
+		before, err := auditJSON(change.Before)
+		if err != nil {
+			return fmt.Errorf("encoding audit before of %s: %w", change.EntityType, err)
+		}
+		after, err := auditJSON(change.After)
+		if err != nil {
+			return fmt.Errorf("encoding audit after of %s: %w", change.EntityType, err)
+		}
+
+		_, err = tx.ExecContext(ctx, recordAudit.Query,
+			ActorFromContext(ctx),
+			change.Action,
+			change.EntityType,
+			fmt.Sprint(change.EntityID),
+			before,
+			after,
+			requestID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func auditJSON(v interface{}) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	s := string(encoded)
+	return &s, nil
+}
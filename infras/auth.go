@@ -0,0 +1,12 @@
+package infras
+
+import (
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/pkg/token"
+)
+
+// ProvideTokenIssuer is the provider for the issuer of access tokens, signed
+// with AUTH.TOKEN_SECRET and named after the application.
+func ProvideTokenIssuer(config *configs.Config) *token.Issuer {
+	return token.NewIssuer([]byte(config.Auth.TokenSecret.Value()), config.App.Name, config.Auth.TokenTTL)
+}
@@ -0,0 +1,12 @@
+package infras
+
+import (
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/pkg/errreport"
+)
+
+// ProvideErrorReporter is the provider for the error reporter. No
+// error-tracking service is configured yet, so errors are only logged.
+func ProvideErrorReporter(config *configs.Config) errreport.Reporter {
+	return errreport.Nop{}
+}
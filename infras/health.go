@@ -0,0 +1,12 @@
+package infras
+
+import "github.com/sanika-farm/sanika-farm-be/pkg/health"
+
+// ProvideHealthRegistry is the provider for the readiness check registry. It
+// registers the checks of every piece of infrastructure.
+func ProvideHealthRegistry(db *PostgresConn, redis *RedisConn) *health.Registry {
+	registry := health.NewRegistry()
+	db.RegisterHealthChecks(registry)
+	redis.RegisterHealthChecks(registry)
+	return registry
+}
@@ -0,0 +1,138 @@
+// Package infrastest provides a fake Postgres connection for testing
+// repositories and the requests using them without a database.
+package infrastest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+)
+
+// Statement is a query or statement run against the fake database.
+type Statement struct {
+	Query string
+	Args  []driver.Value
+}
+
+// Recorder records the statements run against the fake database. Queries
+// return no rows unless Returns sets their result, and statements affect no
+// rows.
+type Recorder struct {
+	mu         sync.Mutex
+	statements []Statement
+	results    []result
+}
+
+type result struct {
+	substr  string
+	columns []string
+	rows    [][]driver.Value
+}
+
+// Returns makes queries containing substr return rows of columns. Later
+// calls take precedence.
+func (r *Recorder) Returns(substr string, columns []string, rows ...[]driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append([]result{{substr: substr, columns: columns, rows: rows}}, r.results...)
+}
+
+// Statements returns the statements run containing substr, in order.
+func (r *Recorder) Statements(substr string) []Statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var res []Statement
+	for _, st := range r.statements {
+		if strings.Contains(st.Query, substr) {
+			res = append(res, st)
+		}
+	}
+	return res
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue) result {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, Statement{Query: query, Args: values})
+	for _, res := range r.results {
+		if strings.Contains(query, res.substr) {
+			return res
+		}
+	}
+	return result{}
+}
+
+// NewPostgresConn returns a PostgresConn whose read and write pools are the
+// same fake database, and the Recorder of the statements run on it.
+func NewPostgresConn(t testing.TB) (*infras.PostgresConn, *Recorder) {
+	rec := &Recorder{}
+	db := sqlx.NewDb(sql.OpenDB(connector{rec}), "postgres")
+	t.Cleanup(func() { _ = db.Close() })
+	pool := &infras.DB{DB: db}
+	return &infras.PostgresConn{Read: pool, Write: pool}, rec
+}
+
+type connector struct{ rec *Recorder }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return conn(c), nil }
+
+func (c connector) Driver() driver.Driver { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, driver.ErrSkip }
+
+type conn struct{ rec *Recorder }
+
+func (c conn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+
+func (c conn) Close() error { return nil }
+
+func (c conn) Begin() (driver.Tx, error) { return tx{}, nil }
+
+func (c conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) { return tx{}, nil }
+
+func (c conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.rec.record(query, args)
+	return driver.RowsAffected(0), nil
+}
+
+func (c conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	res := c.rec.record(query, args)
+	return &rows{columns: res.columns, rows: res.rows}, nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error { return nil }
+
+func (tx) Rollback() error { return nil }
+
+type rows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *rows) Columns() []string { return r.columns }
+
+func (r *rows) Close() error { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,11 @@
+package infras
+
+import "github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+
+// ProvideMetricsRegistry is the provider for the metrics registry. It
+// registers the metrics of every piece of infrastructure.
+func ProvideMetricsRegistry(db *PostgresConn) *metrics.Registry {
+	registry := metrics.NewRegistry()
+	db.RegisterMetrics(registry)
+	return registry
+}
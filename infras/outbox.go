@@ -0,0 +1,231 @@
+package infras
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/pkg/events"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+)
+
+var outbox = struct {
+	InsertQuery string
+	ClaimQuery  string
+	DoneQuery   string
+	RetryQuery  string
+	FailQuery   string
+}{
+	InsertQuery: `INSERT INTO outbox_events (name, payload, request_id)
+		VALUES ($1, $2, $3)`,
+	// Claimed events are leased by pushing available_at past the lease, so
+	// another relay only picks them up again if this one dies mid-delivery.
+	ClaimQuery: `UPDATE outbox_events
+		SET attempts = attempts + 1, available_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE dispatched_at IS NULL AND failed_at IS NULL AND available_at <= NOW()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, name, payload, occurred_at, attempts, request_id`,
+	DoneQuery:  `UPDATE outbox_events SET dispatched_at = NOW(), last_error = NULL WHERE id = $1`,
+	RetryQuery: `UPDATE outbox_events SET available_at = NOW() + make_interval(secs => $2), last_error = $3 WHERE id = $1`,
+	FailQuery:  `UPDATE outbox_events SET failed_at = NOW(), last_error = $2 WHERE id = $1`,
+}
+
+// PublishEvents writes events to the outbox in tx, so they are only
+// dispatched if the change that raised them commits. The request ID in ctx
+// is kept for the relay's logs.
+func PublishEvents(ctx context.Context, tx *Tx, evs ...events.Event) error {
+	var requestID *string
+	if id := logger.RequestID(ctx); id != "" {
+		requestID = &id
+	}
+
+	for _, event := range evs {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encoding event %s: %w", event.EventName(), err)
+		}
+		if _, err = tx.ExecContext(ctx, outbox.InsertQuery, event.EventName(), string(payload), requestID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProvideEventBus is the provider for the domain event bus.
+func ProvideEventBus() *events.Bus {
+	return events.NewBus()
+}
+
+// OutboxRelay dispatches events from the outbox to the subscribers on the
+// bus. Events whose delivery fails are retried with exponential backoff and
+// marked as failed after the configured number of attempts. Several relays
+// may run against the same database.
+type OutboxRelay struct {
+	db  *PostgresConn
+	bus *events.Bus
+	cfg configs.Outbox
+
+	dispatched *metrics.Counter
+	failed     *metrics.Counter
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// ProvideOutboxRelay is the provider for the outbox relay. It does not poll
+// until Start is called.
+func ProvideOutboxRelay(db *PostgresConn, bus *events.Bus, config *configs.Config, registry *metrics.Registry) *OutboxRelay {
+	return &OutboxRelay{
+		db:         db,
+		bus:        bus,
+		cfg:        config.Events.Outbox,
+		dispatched: registry.NewCounter("outbox_events_dispatched_total", "Total number of outbox events delivered to their subscribers.", "event"),
+		failed:     registry.NewCounter("outbox_events_failed_total", "Total number of failed outbox event deliveries.", "event"),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start polls the outbox in the background until Shutdown. Subscribers
+// should be registered on the bus before it is called.
+func (r *OutboxRelay) Start() {
+	if r.started.CompareAndSwap(false, true) {
+		go r.run()
+	}
+}
+
+// Shutdown stops polling and waits for the delivery in progress to finish.
+func (r *OutboxRelay) Shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	if !r.started.Load() {
+		return nil
+	}
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *OutboxRelay) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep draining while full batches come back, so a backlog does not
+		// wait a poll interval per batch.
+		for r.relayBatch() == r.cfg.BatchSize {
+			select {
+			case <-r.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+type outboxRow struct {
+	ID         int64     `db:"id"`
+	Name       string    `db:"name"`
+	Payload    []byte    `db:"payload"`
+	OccurredAt time.Time `db:"occurred_at"`
+	Attempts   int       `db:"attempts"`
+	RequestID  *string   `db:"request_id"`
+}
+
+// relayBatch claims and delivers one batch of due events. It returns the
+// number of events claimed.
+func (r *OutboxRelay) relayBatch() int {
+	ctx := WithPrimary(context.Background())
+
+	var rows []outboxRow
+	err := r.db.Writer(ctx).SelectContext(ctx, &rows, outbox.ClaimQuery, r.cfg.BatchSize, r.cfg.Lease.Seconds())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim outbox events.")
+		return 0
+	}
+
+	for _, row := range rows {
+		select {
+		case <-r.stop:
+			// Unprocessed events are picked up again once their lease ends.
+			return len(rows)
+		default:
+		}
+		r.deliver(ctx, row)
+	}
+	return len(rows)
+}
+
+func (r *OutboxRelay) deliver(ctx context.Context, row outboxRow) {
+	if row.RequestID != nil {
+		ctx = logger.WithRequestID(ctx, *row.RequestID)
+	}
+	eventLog := logger.FromContext(ctx).With().Int64("eventId", row.ID).Str("event", row.Name).Int("attempt", row.Attempts).Logger()
+
+	handleCtx, cancel := context.WithTimeout(ctx, r.cfg.Lease)
+	err := r.bus.Dispatch(handleCtx, events.Message{
+		ID:         row.ID,
+		Name:       row.Name,
+		Payload:    row.Payload,
+		OccurredAt: row.OccurredAt,
+		Attempt:    row.Attempts,
+	})
+	cancel()
+
+	if err == nil {
+		r.dispatched.Inc(row.Name)
+		if _, err = r.db.Writer(ctx).ExecContext(ctx, outbox.DoneQuery, row.ID); err != nil {
+			eventLog.Error().Err(err).Msg("Failed to mark outbox event as dispatched; it will be delivered again.")
+		}
+		return
+	}
+
+	r.failed.Inc(row.Name)
+	lastError := err.Error()
+	if row.Attempts >= r.cfg.MaxAttempts {
+		eventLog.Error().Err(err).Msg("Giving up on outbox event.")
+		if _, err = r.db.Writer(ctx).ExecContext(ctx, outbox.FailQuery, row.ID, lastError); err != nil {
+			eventLog.Error().Err(err).Msg("Failed to mark outbox event as failed.")
+		}
+		return
+	}
+
+	delay := RetryDelay(r.cfg.InitialBackoff, r.cfg.MaxBackoff, row.Attempts)
+	eventLog.Warn().Err(err).Dur("retryIn", delay).Msg("Failed to deliver outbox event, retrying.")
+	if _, err = r.db.Writer(ctx).ExecContext(ctx, outbox.RetryQuery, row.ID, delay.Seconds(), lastError); err != nil {
+		eventLog.Error().Err(err).Msg("Failed to schedule outbox event retry.")
+	}
+}
+
+// RetryDelay is the exponential backoff before retrying after attempt: it
+// doubles initial for every attempt after the first, up to maxDelay.
+func RetryDelay(initial, maxDelay time.Duration, attempt int) time.Duration {
+	delay := initial
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -1,127 +1,223 @@
 package infras
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	// Registers the "postgres" driver opened by CreatePostgresDBConnection.
+	_ "github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/pkg/health"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
 )
 
-// TransactionBlock contains a transaction block
-type TransactionBlock func(db *sqlx.Tx, c chan error)
+// connectTimeout bounds a single connection attempt.
+const connectTimeout = 5 * time.Second
 
 // PostgresConn wraps a pair of read/write PostgreSQL connections.
+// Repositories should go through Reader and Writer rather than the pools, so
+// that reads are routed as described on Reader.
 type PostgresConn struct {
-	Read  *sqlx.DB
-	Write *sqlx.DB
+	Read   *DB
+	Write  *DB
+	router *router
 }
 
-// ProvidePostgresConn is the provider for PostgresConn.
-func ProvidePostgresConn(config *configs.Config) *PostgresConn {
-	return &PostgresConn{
-		Read:  CreatePostgresReadConn(*config),
-		Write: CreatePostgresWriteConn(*config),
+// ProvidePostgresConn is the provider for PostgresConn. It waits for both
+// pools to connect, retrying with backoff, and starts monitoring the read
+// replica's lag.
+func ProvidePostgresConn(config *configs.Config) (*PostgresConn, error) {
+	write, err := CreatePostgresWriteConn(*config)
+	if err != nil {
+		return nil, err
+	}
+	read, err := CreatePostgresReadConn(*config)
+	if err != nil {
+		write.Close()
+		return nil, err
+	}
+
+	routing := config.DB.Postgres.Routing
+	conn := &PostgresConn{
+		Read:   &DB{DB: read, pool: "read"},
+		Write:  &DB{DB: write, pool: "write"},
+		router: newRouter(routing.ReadYourWritesWindow, routing.MaxReplicaLag),
 	}
+	go conn.router.monitor(conn.Read.DB.DB, routing.LagCheckInterval)
+	return conn, nil
 }
 
 // CreatePostgresReadConn creates a database connection for read access.
-func CreatePostgresReadConn(config configs.Config) *sqlx.DB {
-	return CreatePostgresDBConnection(
-		"read",
-		config.DB.Postgres.Read.User,
-		config.DB.Postgres.Read.Password,
-		config.DB.Postgres.Read.Host,
-		config.DB.Postgres.Read.Port,
-		config.DB.Postgres.Read.Name,
-		config.DB.Postgres.Read.SSLMode,
-		config.DB.Postgres.Read.MaxConnLifetime,
-		config.DB.Postgres.Read.MaxIdleConn,
-		config.DB.Postgres.Read.MaxOpenConn)
-
+func CreatePostgresReadConn(config configs.Config) (*sqlx.DB, error) {
+	return CreatePostgresDBConnection("read", config.DB.Postgres.Read, connectBackoff(config))
 }
 
 // CreatePostgresWriteConn creates a database connection for write access.
-func CreatePostgresWriteConn(config configs.Config) *sqlx.DB {
-	return CreatePostgresDBConnection(
-		"write",
-		config.DB.Postgres.Write.User,
-		config.DB.Postgres.Write.Password,
-		config.DB.Postgres.Write.Host,
-		config.DB.Postgres.Write.Port,
-		config.DB.Postgres.Write.Name,
-		config.DB.Postgres.Write.SSLMode,
-		config.DB.Postgres.Write.MaxConnLifetime,
-		config.DB.Postgres.Write.MaxIdleConn,
-		config.DB.Postgres.Write.MaxOpenConn)
+func CreatePostgresWriteConn(config configs.Config) (*sqlx.DB, error) {
+	return CreatePostgresDBConnection("write", config.DB.Postgres.Write, connectBackoff(config))
+}
 
+// Backoff configures how connecting is retried: the delay starts at Initial
+// and doubles up to Max, until MaxWait has passed in total.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	MaxWait time.Duration
 }
 
-// CreatePostgresDBConnection creates a database connection.
-func CreatePostgresDBConnection(connType, username, password, host, port, dbName, sslmode string, maxConnLifetime time.Duration, maxIdleConn, maxOpenConn int) *sqlx.DB {
-	conn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		host,
-		port,
-		username,
-		password,
-		dbName,
-		sslmode)
-
-	if password == "" {
-		conn = fmt.Sprintf(
-			"host=%s port=%s user=%s dbname=%s sslmode=%s",
-			host,
-			port,
-			username,
-			dbName,
-			sslmode)
+func connectBackoff(config configs.Config) Backoff {
+	return Backoff{
+		Initial: config.DB.Postgres.Connect.InitialBackoff,
+		Max:     config.DB.Postgres.Connect.MaxBackoff,
+		MaxWait: config.DB.Postgres.Connect.MaxWait,
 	}
+}
 
-	db, err := sqlx.Connect("postgres", conn)
+// PostgresDSN builds the connection URL of a pool. Building it with url.URL
+// escapes special characters in the user name and password.
+func PostgresDSN(pool configs.PostgresPool) string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		Host:     net.JoinHostPort(pool.Host, pool.Port),
+		Path:     "/" + pool.Name,
+		RawQuery: url.Values{"sslmode": []string{pool.SSLMode}}.Encode(),
+	}
+	if pool.Password.Value() != "" {
+		dsn.User = url.UserPassword(pool.User, pool.Password.Value())
+	} else {
+		dsn.User = url.User(pool.User)
+	}
+	return dsn.String()
+}
+
+// CreatePostgresDBConnection creates a database connection. The database may
+// still be starting, e.g. in docker-compose, so failed attempts are retried
+// with exponential backoff until backoff.MaxWait has passed.
+func CreatePostgresDBConnection(connType string, pool configs.PostgresPool, backoff Backoff) (*sqlx.DB, error) {
+	db, err := sqlx.Open("postgres", PostgresDSN(pool))
 	if err != nil {
+		return nil, fmt.Errorf("opening %s database connection: %w", connType, err)
+	}
+	db.SetConnMaxLifetime(pool.MaxConnLifetime)
+	db.SetMaxIdleConns(pool.MaxIdleConn)
+	db.SetMaxOpenConns(pool.MaxOpenConn)
+
+	deadline := time.Now().Add(backoff.MaxWait)
+	delay := backoff.Initial
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+		err = db.PingContext(ctx)
+		cancel()
+		if err == nil {
+			break
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			db.Close()
+			return nil, fmt.Errorf("connecting to %s database at %s:%s after %d attempts: %w", connType, pool.Host, pool.Port, attempt, err)
+		}
 		log.
-			Fatal().
+			Warn().
 			Err(err).
 			Str("type", connType).
-			Str("host", host).
-			Str("port", port).
-			Str("dbName", dbName).
-			Msg("Failed connecting to Postgres database")
-	} else {
-		log.
-			Info().
-			Str("type", connType).
-			Str("host", host).
-			Str("port", port).
-			Str("dbName", dbName).
-			Msg("Connected to Postgres database")
+			Str("host", pool.Host).
+			Str("port", pool.Port).
+			Int("attempt", attempt).
+			Dur("retryIn", delay).
+			Msg("Failed connecting to Postgres database, retrying")
+		time.Sleep(delay)
+		delay = min(delay*2, backoff.Max)
 	}
 
-	db.SetConnMaxLifetime(maxConnLifetime)
-	db.SetMaxIdleConns(maxIdleConn)
-	db.SetMaxOpenConns(maxOpenConn)
+	log.
+		Info().
+		Str("type", connType).
+		Str("host", pool.Host).
+		Str("port", pool.Port).
+		Str("dbName", pool.Name).
+		Msg("Connected to Postgres database")
+	return db, nil
+}
 
-	return db
+// RegisterHealthChecks registers a ping of the write pool, and an optional
+// check of the read pool: reads fall back to the primary while the replica is
+// down or lagging, so it does not make the server unready.
+func (m *PostgresConn) RegisterHealthChecks(registry *health.Registry) {
+	registry.RegisterOptional("postgres.read", m.checkReplica)
+	registry.Register("postgres.write", m.Write.PingContext)
 }
 
-// WithTransaction performs queries with transaction
-func (m *PostgresConn) WithTransaction(block TransactionBlock) (err error) {
-	e := make(chan error)
-	tx, err := m.Write.Beginx()
-	if err != nil {
-		log.Err(err).Msg("error begin transaction")
-		return
+func (m *PostgresConn) checkReplica(ctx context.Context) error {
+	if err := m.Read.PingContext(ctx); err != nil {
+		return fmt.Errorf("reads fall back to the primary: %w", err)
 	}
-	go block(tx, e)
-	err = <-e
-	if err != nil {
-		if errTx := tx.Rollback(); errTx != nil {
-			log.Err(errTx).Msg("error rollback transaction")
+	if m.router != nil && !m.router.replicaOK.Load() {
+		return fmt.Errorf("replica is lagging %s behind, reads fall back to the primary", time.Duration(m.router.lag.Load()))
+	}
+	return nil
+}
+
+// Close closes both the read and write pools. Closing waits for running
+// queries to finish, so it gives up when the context is done.
+func (m *PostgresConn) Close(ctx context.Context) error {
+	m.router.close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- errors.Join(m.Read.Close(), m.Write.Close())
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RegisterMetrics exposes the connection pool statistics of both pools,
+// labelled by pool.
+func (m *PostgresConn) RegisterMetrics(registry *metrics.Registry) {
+	pools := map[string]*DB{"read": m.Read, "write": m.Write}
+	stat := func(value func(s sql.DBStats) float64) func() []metrics.Sample {
+		return func() []metrics.Sample {
+			samples := make([]metrics.Sample, 0, len(pools))
+			for _, pool := range []string{"read", "write"} {
+				samples = append(samples, metrics.Sample{
+					LabelValues: []string{pool},
+					Value:       value(pools[pool].Stats()),
+				})
+			}
+			return samples
 		}
-		return
 	}
-	err = tx.Commit()
-	return
+	labels := []string{"pool"}
+
+	registry.NewGaugeFunc("db_pool_max_open_connections", "Maximum number of open connections to the database.", labels,
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }))
+	registry.NewGaugeFunc("db_pool_open_connections", "Number of established connections, both in use and idle.", labels,
+		stat(func(s sql.DBStats) float64 { return float64(s.OpenConnections) }))
+	registry.NewGaugeFunc("db_pool_in_use_connections", "Number of connections currently in use.", labels,
+		stat(func(s sql.DBStats) float64 { return float64(s.InUse) }))
+	registry.NewGaugeFunc("db_pool_idle_connections", "Number of idle connections.", labels,
+		stat(func(s sql.DBStats) float64 { return float64(s.Idle) }))
+	registry.NewCounterFunc("db_pool_wait_count_total", "Total number of connections waited for.", labels,
+		stat(func(s sql.DBStats) float64 { return float64(s.WaitCount) }))
+	registry.NewCounterFunc("db_pool_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", labels,
+		stat(func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }))
+	registry.NewCounterFunc("db_pool_max_idle_closed_total", "Total number of connections closed due to SetMaxIdleConns.", labels,
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }))
+	registry.NewCounterFunc("db_pool_max_lifetime_closed_total", "Total number of connections closed due to SetConnMaxLifetime.", labels,
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }))
+	registry.NewGaugeFunc("db_replica_lag_seconds", "Replication lag of the read pool, as last measured.", nil,
+		func() []metrics.Sample {
+			return []metrics.Sample{{Value: time.Duration(m.router.lag.Load()).Seconds()}}
+		})
 }
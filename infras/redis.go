@@ -0,0 +1,94 @@
+package infras
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/pkg/cache"
+	"github.com/sanika-farm/sanika-farm-be/pkg/health"
+)
+
+const (
+	redisDialTimeout  = 5 * time.Second
+	redisReadTimeout  = 3 * time.Second
+	redisWriteTimeout = 3 * time.Second
+	redisPoolSize     = 10
+	redisPoolTimeout  = 4 * time.Second
+)
+
+// RedisConn is the connection to the primary Redis server. Connections are
+// dialled lazily, so the API can start while Redis is still unavailable.
+// Every command is bounded by the read and write timeouts even when its
+// context has no deadline, and the pool never holds more than
+// redisPoolSize connections.
+type RedisConn struct {
+	client *redis.Client
+}
+
+// ProvideRedisConn is the provider for RedisConn.
+func ProvideRedisConn(config *configs.Config) *RedisConn {
+	primary := config.Cache.Redis.Primary
+	if primary.Host == "" {
+		log.Warn().Msg("Redis host is not set, caching is disabled.")
+		return &RedisConn{}
+	}
+
+	log.Info().Str("host", primary.Host).Str("port", primary.Port).Msg("Redis configured.")
+	return &RedisConn{
+		client: redis.NewClient(&redis.Options{
+			Addr:         net.JoinHostPort(primary.Host, primary.Port),
+			Password:     primary.Password.Value(),
+			DialTimeout:  redisDialTimeout,
+			ReadTimeout:  redisReadTimeout,
+			WriteTimeout: redisWriteTimeout,
+			PoolSize:     redisPoolSize,
+			PoolTimeout:  redisPoolTimeout,
+		}),
+	}
+}
+
+// Enabled reports whether a Redis server is configured.
+func (r *RedisConn) Enabled() bool {
+	return r != nil && r.client != nil
+}
+
+// Client returns the Redis client, or nil when Redis is not configured.
+func (r *RedisConn) Client() *redis.Client {
+	if !r.Enabled() {
+		return nil
+	}
+	return r.client
+}
+
+// Ping checks that Redis answers.
+func (r *RedisConn) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+// RegisterHealthChecks registers a ping of Redis when it is configured.
+func (r *RedisConn) RegisterHealthChecks(registry *health.Registry) {
+	if r.Enabled() {
+		registry.Register("redis.primary", r.Ping)
+	}
+}
+
+// Close closes the connection pool.
+func (r *RedisConn) Close(ctx context.Context) error {
+	if !r.Enabled() {
+		return nil
+	}
+	return r.client.Close()
+}
+
+// ProvideCache is the provider for the cache-aside helper backed by Redis.
+// Caching is disabled when Redis is not configured.
+func ProvideCache(redis *RedisConn) *cache.Cache {
+	if !redis.Enabled() {
+		return cache.New(nil)
+	}
+	return cache.New(redis.Client())
+}
@@ -0,0 +1,141 @@
+package infras
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// replicaLagQuery returns how far the replica's replayed data is behind, in
+// seconds. It is zero when the replica has replayed everything it received,
+// so an idle primary does not look like lag, and when the read pool points
+// at a primary, where both functions return NULL.
+const replicaLagQuery = `
+	SELECT COALESCE(
+		CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()) END,
+	0)`
+
+type consistencyKey struct{}
+
+// WithConsistencyKey returns a copy of ctx identifying the client, usually by
+// user ID, for read-your-writes routing.
+func WithConsistencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, consistencyKey{}, key)
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a copy of ctx whose reads always go to the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// router decides which pool serves reads. Reads go to the replica unless
+// the client wrote within the read-your-writes window, or the replica is
+// down or lagging beyond the maximum.
+type router struct {
+	window time.Duration
+	maxLag time.Duration
+
+	mu         sync.Mutex
+	lastWrites map[string]time.Time
+
+	replicaOK atomic.Bool
+	lag       atomic.Int64
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+func newRouter(window, maxLag time.Duration) *router {
+	r := &router{
+		window:     window,
+		maxLag:     maxLag,
+		lastWrites: make(map[string]time.Time),
+		stop:       make(chan struct{}),
+	}
+	r.replicaOK.Store(true)
+	return r
+}
+
+// wrote records a write by the client in ctx.
+func (r *router) wrote(ctx context.Context) {
+	key, _ := ctx.Value(consistencyKey{}).(string)
+	if key == "" || r.window <= 0 {
+		return
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastWrites[key] = now
+	// Drop expired entries once in a while, so the map stays bounded.
+	if len(r.lastWrites) > 10000 {
+		for k, at := range r.lastWrites {
+			if now.Sub(at) > r.window {
+				delete(r.lastWrites, k)
+			}
+		}
+	}
+}
+
+// usePrimary reports whether reads in ctx must go to the primary.
+func (r *router) usePrimary(ctx context.Context) bool {
+	if forced, _ := ctx.Value(primaryKey{}).(bool); forced {
+		return true
+	}
+	if !r.replicaOK.Load() {
+		return true
+	}
+
+	key, _ := ctx.Value(consistencyKey{}).(string)
+	if key == "" || r.window <= 0 {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	at, ok := r.lastWrites[key]
+	return ok && time.Since(at) < r.window
+}
+
+// monitor checks the replica's health and lag every interval until stopped.
+func (r *router) monitor(replica *sql.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.check(replica, interval)
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *router) check(replica *sql.DB, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var lagSeconds float64
+	err := replica.QueryRowContext(ctx, replicaLagQuery).Scan(&lagSeconds)
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	r.lag.Store(int64(lag))
+
+	ok := err == nil && lag <= r.maxLag
+	if was := r.replicaOK.Swap(ok); was != ok {
+		if ok {
+			log.Info().Dur("lag", lag).Msg("Read replica recovered, routing reads to it again.")
+		} else {
+			log.Warn().Err(err).Dur("lag", lag).Dur("maxLag", r.maxLag).Msg("Read replica is down or lagging, routing reads to the primary.")
+		}
+	}
+}
+
+func (r *router) close() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
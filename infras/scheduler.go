@@ -0,0 +1,278 @@
+package infras
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/pkg/cron"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+)
+
+const (
+	// defaultJobTimeout bounds jobs registered without a timeout.
+	defaultJobTimeout = time.Hour
+	// jobBookkeepingTimeout bounds releasing the lock and saving the result,
+	// which run after the job's own context may have ended.
+	jobBookkeepingTimeout = 5 * time.Second
+)
+
+var jobRuns = struct {
+	LockQuery   string
+	UnlockQuery string
+	StartQuery  string
+	FinishQuery string
+}{
+	LockQuery:   `SELECT pg_try_advisory_lock($1)`,
+	UnlockQuery: `SELECT pg_advisory_unlock($1)`,
+	// The unique (job_name, scheduled_at) key keeps a run from repeating on
+	// a replica whose timer fires after another replica released the lock.
+	StartQuery: `INSERT INTO job_runs (job_name, scheduled_at, started_at, status, instance)
+		VALUES ($1, $2, NOW(), 'running', $3)
+		ON CONFLICT (job_name, scheduled_at) DO NOTHING
+		RETURNING id`,
+	FinishQuery: `UPDATE job_runs
+		SET status = $2, finished_at = NOW(), duration_ms = $3, error = $4
+		WHERE id = $1`,
+}
+
+// JobFunc runs one occurrence of a scheduled job. scheduledAt is the time
+// the occurrence was due, so a job can work on the period before it. ctx
+// is cancelled when the job times out or the scheduler is shut down.
+type JobFunc func(ctx context.Context, scheduledAt time.Time) error
+
+// Job is a recurring job. Schedule is a cron expression as accepted by
+// cron.Parse, evaluated in the configured JOBS.TIMEZONE.
+type Job struct {
+	Name     string
+	Schedule string
+	Timeout  time.Duration
+	Run      JobFunc
+}
+
+type scheduledJob struct {
+	Job
+	schedule cron.Schedule
+	lockKey  int64
+}
+
+// Scheduler runs recurring jobs. Each occurrence runs on one replica only:
+// it takes a Postgres advisory lock for the job and records the run in
+// job_runs with its status and duration.
+type Scheduler struct {
+	db       *PostgresConn
+	loc      *time.Location
+	instance string
+
+	mu   sync.Mutex
+	jobs []scheduledJob
+
+	runs     *metrics.Counter
+	duration *metrics.Histogram
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	// jobCtx is cancelled when shutdown runs out of time, to interrupt jobs.
+	jobCtx    context.Context
+	cancelJob context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// ProvideScheduler is the provider for the job scheduler. Jobs are
+// registered by the services that own them; nothing runs until Start.
+func ProvideScheduler(db *PostgresConn, config *configs.Config, registry *metrics.Registry) *Scheduler {
+	loc, err := time.LoadLocation(config.Jobs.Timezone)
+	if err != nil {
+		log.Warn().Err(err).Str("timezone", config.Jobs.Timezone).Msg("Unknown job timezone, using UTC.")
+		loc = time.UTC
+	}
+	instance, _ := os.Hostname()
+	jobCtx, cancelJob := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		db:        db,
+		loc:       loc,
+		instance:  instance,
+		runs:      registry.NewCounter("job_runs_total", "Total number of scheduled job runs by status.", "job", "status"),
+		duration:  registry.NewHistogram("job_run_duration_seconds", "Duration of scheduled job runs.", []float64{0.1, 1, 10, 60, 300, 1800, 3600}, "job"),
+		stop:      make(chan struct{}),
+		jobCtx:    jobCtx,
+		cancelJob: cancelJob,
+	}
+}
+
+// Register adds a job. It panics if the schedule is invalid or the name is
+// taken, as both are fixed in code. Jobs registered after Start never run.
+func (s *Scheduler) Register(job Job) {
+	schedule := cron.MustParse(job.Schedule)
+	if job.Timeout <= 0 {
+		job.Timeout = defaultJobTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range s.jobs {
+		if j.Name == job.Name {
+			panic(fmt.Sprintf("scheduler: job %q registered twice", job.Name))
+		}
+	}
+	s.jobs = append(s.jobs, scheduledJob{Job: job, schedule: schedule, lockKey: jobLockKey(job.Name)})
+}
+
+// Start runs the registered jobs on their schedules until Shutdown.
+func (s *Scheduler) Start() {
+	if !s.started.CompareAndSwap(false, true) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+		log.Info().Str("job", job.Name).Str("schedule", job.Schedule).Msg("Scheduled job.")
+	}
+}
+
+// Shutdown stops scheduling and waits for running jobs to finish. Jobs still
+// running when ctx is done are cancelled.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancelJob()
+		return nil
+	case <-ctx.Done():
+		s.cancelJob()
+		return fmt.Errorf("jobs still running: %w", ctx.Err())
+	}
+}
+
+func (s *Scheduler) loop(job scheduledJob) {
+	defer s.wg.Done()
+
+	for {
+		next := job.schedule.Next(time.Now().In(s.loc))
+		if next.IsZero() {
+			log.Warn().Str("job", job.Name).Str("schedule", job.Schedule).Msg("Job schedule never fires, not scheduling it.")
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+			s.run(job, next)
+		case <-s.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// run runs one occurrence of job if this replica wins the advisory lock and
+// no other replica has run it yet.
+func (s *Scheduler) run(job scheduledJob, scheduledAt time.Time) {
+	ctx, cancel := context.WithTimeout(s.jobCtx, job.Timeout)
+	defer cancel()
+	jobLog := log.With().Str("job", job.Name).Time("scheduledAt", scheduledAt).Logger()
+	ctx = logger.WithContext(WithPrimary(ctx), jobLog)
+
+	// Session-level advisory locks belong to a connection, so the lock is
+	// taken and released on one dedicated connection.
+	conn, err := s.db.Write.Conn(ctx)
+	if err != nil {
+		jobLog.Error().Err(err).Msg("Failed to get a connection for job.")
+		return
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err = conn.QueryRowContext(ctx, jobRuns.LockQuery, job.lockKey).Scan(&locked); err != nil {
+		jobLog.Error().Err(err).Msg("Failed to take job lock.")
+		return
+	}
+	if !locked {
+		jobLog.Debug().Msg("Job is running on another instance, skipping.")
+		return
+	}
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.Background(), jobBookkeepingTimeout)
+		defer cancel()
+		if _, err := conn.ExecContext(unlockCtx, jobRuns.UnlockQuery, job.lockKey); err != nil {
+			jobLog.Error().Err(err).Msg("Failed to release job lock.")
+		}
+	}()
+
+	var runID int64
+	err = conn.QueryRowContext(ctx, jobRuns.StartQuery, job.Name, scheduledAt, s.instance).Scan(&runID)
+	if errors.Is(err, sql.ErrNoRows) {
+		jobLog.Debug().Msg("Job already ran on another instance, skipping.")
+		return
+	}
+	if err != nil {
+		jobLog.Error().Err(err).Msg("Failed to record job run.")
+		return
+	}
+
+	started := time.Now()
+	jobLog.Info().Msg("Job started.")
+	err = runJob(ctx, job, scheduledAt)
+	elapsed := time.Since(started)
+
+	status := "succeeded"
+	var errMsg *string
+	if err != nil {
+		status = "failed"
+		msg := err.Error()
+		errMsg = &msg
+		jobLog.Error().Err(err).Dur("duration", elapsed).Msg("Job failed.")
+	} else {
+		jobLog.Info().Dur("duration", elapsed).Msg("Job succeeded.")
+	}
+	s.runs.Inc(job.Name, status)
+	s.duration.Observe(elapsed.Seconds(), job.Name)
+
+	finishCtx, cancelFinish := context.WithTimeout(context.Background(), jobBookkeepingTimeout)
+	defer cancelFinish()
+	if _, err = conn.ExecContext(finishCtx, jobRuns.FinishQuery, runID, status, elapsed.Milliseconds(), errMsg); err != nil {
+		jobLog.Error().Err(err).Msg("Failed to record job result.")
+	}
+}
+
+// runJob runs job in a span, turning a panic into an error so one broken
+// job does not take down the process.
+func runJob(ctx context.Context, job scheduledJob, scheduledAt time.Time) (err error) {
+	ctx, span := tracer.Start(ctx, "job "+job.Name)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v\n%s", recovered, debug.Stack())
+		}
+		endSpan(span, err)
+	}()
+	return job.Run(ctx, scheduledAt)
+}
+
+// jobLockKey derives the advisory lock key of a job from its name.
+func jobLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("job:" + name))
+	return int64(h.Sum64())
+}
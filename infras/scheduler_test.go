@@ -0,0 +1,183 @@
+package infras_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/infras/infrastest"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+)
+
+// newTestScheduler returns a scheduler over a fake database on which this
+// replica wins the job lock and the run is not recorded yet.
+func newTestScheduler(t *testing.T) (*infras.Scheduler, *infrastest.Recorder) {
+	t.Helper()
+	db, rec := infrastest.NewPostgresConn(t)
+	rec.Returns("pg_try_advisory_lock", []string{"locked"}, []driver.Value{true})
+	rec.Returns("INSERT INTO job_runs", []string{"id"}, []driver.Value{int64(1)})
+	cfg := &configs.Config{}
+	cfg.Jobs.Timezone = "UTC"
+	return infras.ProvideScheduler(db, cfg, metrics.NewRegistry()), rec
+}
+
+func shutdown(t *testing.T, s *infras.Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSchedulerRecordsRuns(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		run        infras.JobFunc
+		wantStatus string
+		wantError  string
+	}{
+		{"success", func(context.Context, time.Time) error { return nil }, "succeeded", ""},
+		{"error", func(context.Context, time.Time) error { return errors.New("no feed data") }, "failed", "no feed data"},
+		{"panic", func(context.Context, time.Time) error { panic("nil map") }, "failed", "panic: nil map"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, rec := newTestScheduler(t)
+			var scheduledAt atomic.Value
+			s.Register(infras.Job{Name: "report", Schedule: "@every 1s", Run: func(ctx context.Context, at time.Time) error {
+				scheduledAt.CompareAndSwap(nil, at)
+				return tt.run(ctx, at)
+			}})
+			s.Start()
+			waitFor(t, "the job result", func() bool { return len(rec.Statements("UPDATE job_runs")) > 0 })
+			shutdown(t, s)
+
+			at, _ := scheduledAt.Load().(time.Time)
+			if at.IsZero() || !at.Equal(at.Truncate(time.Second)) {
+				t.Errorf("job scheduled at %v, want a whole second", at)
+			}
+			start := rec.Statements("INSERT INTO job_runs")[0]
+			if start.Args[0] != "report" || !start.Args[1].(time.Time).Equal(at) {
+				t.Errorf("run recorded as %v, want report at %v", start.Args, at)
+			}
+			finish := rec.Statements("UPDATE job_runs")[0]
+			if finish.Args[1] != tt.wantStatus {
+				t.Errorf("run status = %v, want %s", finish.Args[1], tt.wantStatus)
+			}
+			if errMsg, _ := finish.Args[3].(string); !strings.HasPrefix(errMsg, tt.wantError) || (tt.wantError == "") != (finish.Args[3] == nil) {
+				t.Errorf("run error = %v, want %q", finish.Args[3], tt.wantError)
+			}
+			if len(rec.Statements("pg_advisory_unlock")) == 0 {
+				t.Error("job lock was not released")
+			}
+		})
+	}
+}
+
+func TestSchedulerSkipsRunsOfOtherReplicas(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		setup func(rec *infrastest.Recorder)
+		last  string
+	}{
+		{"lock held by another replica", func(rec *infrastest.Recorder) {
+			rec.Returns("pg_try_advisory_lock", []string{"locked"}, []driver.Value{false})
+		}, "pg_try_advisory_lock"},
+		{"run already recorded", func(rec *infrastest.Recorder) {
+			rec.Returns("INSERT INTO job_runs", []string{"id"})
+		}, "INSERT INTO job_runs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, rec := newTestScheduler(t)
+			tt.setup(rec)
+			var ran atomic.Bool
+			s.Register(infras.Job{Name: "report", Schedule: "@every 1s", Run: func(context.Context, time.Time) error {
+				ran.Store(true)
+				return nil
+			}})
+			s.Start()
+			waitFor(t, "a run attempt", func() bool { return len(rec.Statements(tt.last)) > 0 })
+			shutdown(t, s)
+
+			if ran.Load() {
+				t.Error("job ran, want it skipped")
+			}
+			if n := len(rec.Statements("UPDATE job_runs")); n != 0 {
+				t.Errorf("recorded %d results, want none", n)
+			}
+		})
+	}
+}
+
+func TestSchedulerShutdownCancelsRunningJobs(t *testing.T) {
+	t.Parallel()
+	s, rec := newTestScheduler(t)
+	started := make(chan struct{}, 1)
+	s.Register(infras.Job{Name: "report", Schedule: "@every 1s", Run: func(ctx context.Context, _ time.Time) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	s.Start()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not start")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown = %v, want the deadline exceeded", err)
+	}
+	waitFor(t, "the cancelled job's result", func() bool { return len(rec.Statements("UPDATE job_runs")) > 0 })
+	if status := rec.Statements("UPDATE job_runs")[0].Args[1]; status != "failed" {
+		t.Errorf("cancelled run status = %v, want failed", status)
+	}
+}
+
+func TestSchedulerRegisterPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		jobs []infras.Job
+	}{
+		{"invalid schedule", []infras.Job{{Name: "report", Schedule: "every day"}}},
+		{"name taken", []infras.Job{{Name: "report", Schedule: "@daily"}, {Name: "report", Schedule: "@hourly"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestScheduler(t)
+			defer func() {
+				if recover() == nil {
+					t.Error("Register did not panic")
+				}
+			}()
+			for _, job := range tt.jobs {
+				s.Register(job)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package infras
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts the spans of the infrastructure. It follows the global
+// tracer provider, so it can be created before ProvideTracer runs.
+var tracer = otel.Tracer("github.com/sanika-farm/sanika-farm-be/infras")
+
+// ProvideTracer is the provider for the tracer provider. It also installs the
+// provider and the W3C trace context and baggage propagators globally, so
+// that SQL calls, jobs and the HTTP middleware share them. Without an
+// exporter nothing is sampled, but spans still get IDs that propagate and
+// appear in logs.
+func ProvideTracer(config *configs.Config) (*sdktrace.TracerProvider, error) {
+	tracingConfig := config.Tracing
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(tracingConfig.Exporter) {
+	case "otlp":
+		exporter, err = otlptracehttp.New(context.Background(),
+			otlptracehttp.WithEndpointURL(strings.TrimSuffix(tracingConfig.OTLPEndpoint, "/")+"/v1/traces"))
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "", "none":
+	default:
+		log.Warn().Str("exporter", tracingConfig.Exporter).Msg("Unknown tracing exporter, tracing is disabled.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", config.App.Name),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := tracingConfig.SampleRatio
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	} else {
+		ratio = 0
+	}
+	opts = append(opts, sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))))
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if exporter != nil {
+		log.Info().Str("exporter", tracingConfig.Exporter).Float64("sampleRatio", tracingConfig.SampleRatio).Msg("Tracing enabled.")
+	} else {
+		log.Info().Msg("Tracing exporter is disabled.")
+	}
+	return provider, nil
+}
+
+// endSpan records err, if any, on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// DB is a sqlx.DB whose context-aware calls are traced.
+type DB struct {
+	*sqlx.DB
+	pool string
+}
+
+// Tx is a sqlx.Tx whose context-aware calls are traced.
+type Tx struct {
+	*sqlx.Tx
+	pool       string
+	savepoints int
+}
+
+func startQuerySpan(ctx context.Context, pool, operation, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "db."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.pool", pool),
+			attribute.String("db.statement", query),
+		))
+}
+
+// finishQuerySpan ends a query span. No rows is an answer, not a failure.
+func finishQuerySpan(span trace.Span, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+	endSpan(span, err)
+}
+
+// GetContext runs a traced sqlx GetContext.
+func (db *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, db.pool, "get", query)
+	defer func() { finishQuerySpan(span, err) }()
+	return db.DB.GetContext(ctx, dest, query, args...)
+}
+
+// SelectContext runs a traced sqlx SelectContext.
+func (db *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, db.pool, "select", query)
+	defer func() { finishQuerySpan(span, err) }()
+	return db.DB.SelectContext(ctx, dest, query, args...)
+}
+
+// ExecContext runs a traced sqlx ExecContext.
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	ctx, span := startQuerySpan(ctx, db.pool, "exec", query)
+	defer func() { finishQuerySpan(span, err) }()
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+// QueryRowxContext runs a traced sqlx QueryRowxContext. The span covers
+// running the query, not scanning the row.
+func (db *DB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startQuerySpan(ctx, db.pool, "query_row", query)
+	row := db.DB.QueryRowxContext(ctx, query, args...)
+	finishQuerySpan(span, row.Err())
+	return row
+}
+
+// BeginTxx starts a transaction whose statements are traced.
+func (db *DB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, pool: db.pool}, nil
+}
+
+// GetContext runs a traced sqlx GetContext within the transaction.
+func (tx *Tx) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, tx.pool, "get", query)
+	defer func() { finishQuerySpan(span, err) }()
+	return tx.Tx.GetContext(ctx, dest, query, args...)
+}
+
+// SelectContext runs a traced sqlx SelectContext within the transaction.
+func (tx *Tx) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, tx.pool, "select", query)
+	defer func() { finishQuerySpan(span, err) }()
+	return tx.Tx.SelectContext(ctx, dest, query, args...)
+}
+
+// ExecContext runs a traced sqlx ExecContext within the transaction.
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	ctx, span := startQuerySpan(ctx, tx.pool, "exec", query)
+	defer func() { finishQuerySpan(span, err) }()
+	return tx.Tx.ExecContext(ctx, query, args...)
+}
+
+// QueryRowxContext runs a traced sqlx QueryRowxContext within the
+// transaction. The span covers running the query, not scanning the row.
+func (tx *Tx) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := startQuerySpan(ctx, tx.pool, "query_row", query)
+	row := tx.Tx.QueryRowxContext(ctx, query, args...)
+	finishQuerySpan(span, row.Err())
+	return row
+}
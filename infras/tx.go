@@ -0,0 +1,195 @@
+package infras
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+const (
+	// defaultTxRetries is the number of retries on serialization failures
+	// when TxOptions does not set MaxRetries.
+	defaultTxRetries = 3
+	txRetryBaseDelay = 10 * time.Millisecond
+)
+
+// TxOptions configures a transaction started by RunInTx. A nil *TxOptions
+// runs with the database's default isolation level, read-write, with the
+// default number of retries.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+	// MaxRetries is how many times the transaction is retried after a
+	// serialization failure or deadlock. Negative disables retries.
+	MaxRetries int
+}
+
+// TxFunc is the body of a transaction. It must use ctx for every query, so
+// that repository calls made with it join the transaction.
+type TxFunc func(ctx context.Context, tx *Tx) error
+
+// Queryer is implemented by both *DB and *Tx, so repositories can run the
+// same queries inside or outside a transaction.
+type Queryer interface {
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row
+	Rebind(query string) string
+}
+
+type txKey struct{}
+
+// TxFromContext returns the transaction carried by ctx, or nil.
+func TxFromContext(ctx context.Context) *Tx {
+	tx, _ := ctx.Value(txKey{}).(*Tx)
+	return tx
+}
+
+// Reader returns the transaction carried by ctx, or the pool that should serve
+// reads: the read pool, unless read-your-writes or replica lag routes the read
+// to the write pool.
+func (m *PostgresConn) Reader(ctx context.Context) Queryer {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	if m.router != nil && m.router.usePrimary(ctx) {
+		return m.Write
+	}
+	return m.Read
+}
+
+// Writer returns the transaction carried by ctx, or the write pool. It
+// records the write for read-your-writes routing.
+func (m *PostgresConn) Writer(ctx context.Context) Queryer {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	if m.router != nil {
+		m.router.wrote(ctx)
+	}
+	return m.Write
+}
+
+// RunInTx runs fn in a transaction on the write pool, committing if fn
+// returns nil and rolling back if it returns an error or panics.
+//
+// The transaction is carried on the context passed to fn, so services can
+// compose several repository calls in one transaction. When ctx already
+// carries a transaction, fn runs in a savepoint of it instead and opts are
+// ignored; an error then only rolls back to the savepoint.
+//
+// Transactions failing with a serialization failure or deadlock are retried
+// from the start with a short backoff, so fn must be safe to run again.
+func (m *PostgresConn) RunInTx(ctx context.Context, opts *TxOptions, fn TxFunc) error {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx.runInSavepoint(ctx, fn)
+	}
+	if opts == nil {
+		opts = &TxOptions{}
+	}
+	retries := opts.MaxRetries
+	if retries == 0 {
+		retries = defaultTxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.runInTx(ctx, opts, fn)
+		if err == nil || !isSerializationFailure(err) || attempt >= retries {
+			return err
+		}
+
+		delay := txRetryBaseDelay<<attempt + time.Duration(rand.Int63n(int64(txRetryBaseDelay)))
+		logger.FromContext(ctx).Warn().Err(err).Int("attempt", attempt+1).Dur("delay", delay).Msg("Retrying transaction after serialization failure.")
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+func (m *PostgresConn) runInTx(ctx context.Context, opts *TxOptions, fn TxFunc) (err error) {
+	ctx, span := tracer.Start(ctx, "db.transaction")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := m.Write.BeginTxx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if !opts.ReadOnly && m.router != nil {
+		m.router.wrote(ctx)
+	}
+	ctx = context.WithValue(ctx, txKey{}, tx)
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			rollback(ctx, tx)
+			panic(recovered)
+		}
+	}()
+
+	if err = fn(ctx, tx); err != nil {
+		rollback(ctx, tx)
+		return err
+	}
+	return tx.Commit()
+}
+
+func rollback(ctx context.Context, tx *Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		logger.FromContext(ctx).Error().Err(err).Msg("error rollback transaction")
+	}
+}
+
+func (tx *Tx) runInSavepoint(ctx context.Context, fn TxFunc) (err error) {
+	tx.savepoints++
+	name := fmt.Sprintf("sp_%d", tx.savepoints)
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(recovered)
+		}
+	}()
+
+	if err = fn(ctx, tx); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback to savepoint: %w", rbErr))
+		}
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// isSerializationFailure reports whether err is a Postgres serialization
+// failure (40001) or deadlock (40P01). The pq driver, like pgx, exposes the
+// SQLSTATE of *pq.Error through a SQLState method.
+func isSerializationFailure(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.SQLState() {
+	case "40001", "40P01":
+		return true
+	}
+	return false
+}
+
+// IsUniqueViolation reports whether err is a Postgres unique violation
+// (23505), such as inserting a duplicate key.
+func IsUniqueViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "23505"
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Entry is one recorded change in the audit trail. Before and After hold the
+// entity as JSON and are null for creations and deletions respectively.
+type Entry struct {
+	ID          int              `db:"id"`
+	ActorUserID *int             `db:"actor_user_id"`
+	Action      string           `db:"action"`
+	EntityType  string           `db:"entity_type"`
+	EntityID    string           `db:"entity_id"`
+	Before      *json.RawMessage `db:"before"`
+	After       *json.RawMessage `db:"after"`
+	RequestID   *string          `db:"request_id"`
+	CreatedAt   time.Time        `db:"created_at"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/audit/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+// ListAuditResource is the allow-list of fields the audit trail can be sorted
+// and filtered by. id filters by the ID of the changed entity; the audit
+// entry's own ID is auditId.
+var ListAuditResource = pagination.Resource{
+	Columns: map[string]string{
+		"auditId":     "id",
+		"entity":      "entity_type",
+		"id":          "entity_id",
+		"action":      "action",
+		"actorUserId": "actor_user_id",
+		"createdAt":   "created_at",
+	},
+	Sortable: []string{"auditId", "createdAt"},
+	Filterable: map[string]pagination.FieldType{
+		"entity":      pagination.String,
+		"id":          pagination.String,
+		"action":      pagination.String,
+		"actorUserId": pagination.Int,
+		"createdAt":   pagination.Time,
+	},
+	DefaultSort: "-auditId",
+	CursorField: "auditId",
+	CursorType:  pagination.Int,
+}
+
+type AuditEntryResponse struct {
+	ID          int              `json:"id"`
+	ActorUserID *int             `json:"actorUserId"`
+	Action      string           `json:"action"`
+	Entity      string           `json:"entity"`
+	EntityID    string           `json:"entityId"`
+	Before      *json.RawMessage `json:"before" swaggertype:"object"`
+	After       *json.RawMessage `json:"after" swaggertype:"object"`
+	RequestID   *string          `json:"requestId"`
+	CreatedAt   time.Time        `json:"createdAt"`
+}
+
+func NewAuditEntryResponse(e model.Entry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:          e.ID,
+		ActorUserID: e.ActorUserID,
+		Action:      e.Action,
+		Entity:      e.EntityType,
+		EntityID:    e.EntityID,
+		Before:      e.Before,
+		After:       e.After,
+		RequestID:   e.RequestID,
+		CreatedAt:   e.CreatedAt,
+	}
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/audit/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+var (
+	listAuditEntries = struct {
+		Query      string
+		CountQuery string
+	}{
+		Query: `SELECT id, actor_user_id, action, entity_type, entity_id, before, after, request_id, created_at
+			FROM audit_log`,
+		CountQuery: `SELECT COUNT(*) FROM audit_log`,
+	}
+)
+
+type AuditEntryRepository interface {
+	ListAuditEntries(ctx context.Context, params pagination.Params) ([]model.Entry, int, error)
+}
+
+// ListAuditEntries returns one page of the audit trail. The total is only
+// counted for offset pagination and is zero for cursor pages.
+func (r *AuditRepositoryImpl) ListAuditEntries(ctx context.Context, params pagination.Params) ([]model.Entry, int, error) {
+	where, args := params.Where()
+	limit, limitArgs := params.LimitOffset(len(args))
+
+	entries := []model.Entry{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &entries, listAuditEntries.Query+where+params.OrderBy()+limit, append(args, limitArgs...)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if !params.IsCursor() {
+		countWhere, countArgs := params.CountWhere()
+		if err = r.DB.Reader(ctx).GetContext(ctx, &total, listAuditEntries.CountQuery+countWhere, countArgs...); err != nil {
+			return nil, 0, err
+		}
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/sanika-farm/sanika-farm-be/infras"
+
+// AuditRepository is the interface for repository.
+type AuditRepository interface {
+	AuditEntryRepository
+}
+
+type AuditRepositoryImpl struct {
+	DB *infras.PostgresConn
+}
+
+func ProvideAuditRepository(db *infras.PostgresConn) *AuditRepositoryImpl {
+	return &AuditRepositoryImpl{
+		DB: db,
+	}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/audit/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/audit/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+type AuditEntryService interface {
+	ListAuditEntries(ctx context.Context, params pagination.Params) ([]dto.AuditEntryResponse, pagination.Metadata, error)
+}
+
+func (s AuditServiceImpl) ListAuditEntries(ctx context.Context, params pagination.Params) ([]dto.AuditEntryResponse, pagination.Metadata, error) {
+	entries, total, err := s.AuditRepository.ListAuditEntries(ctx, params)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to list audit entries")
+		return nil, pagination.Metadata{}, failure.InternalError(err)
+	}
+
+	entries, metadata := pagination.Page(params, entries, total, func(e model.Entry) string {
+		return strconv.Itoa(e.ID)
+	})
+
+	res := make([]dto.AuditEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, dto.NewAuditEntryResponse(e))
+	}
+	return res, metadata, nil
+}
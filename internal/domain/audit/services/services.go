@@ -0,0 +1,22 @@
+package services
+
+import (
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/audit/repository"
+)
+
+type AuditService interface {
+	AuditEntryService
+}
+
+type AuditServiceImpl struct {
+	AuditRepository repository.AuditRepository
+	cfg             *configs.Config
+}
+
+func ProvideAuditService(repo repository.AuditRepository, cfg *configs.Config) *AuditServiceImpl {
+	return &AuditServiceImpl{
+		AuditRepository: repo,
+		cfg:             cfg,
+	}
+}
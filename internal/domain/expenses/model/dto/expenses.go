@@ -0,0 +1,140 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model"
+	"github.com/shopspring/decimal"
+)
+
+type ExpenseAllocationRequest struct {
+	Enterprise model.Enterprise `json:"enterprise"`
+	PenID      *int             `json:"penId,omitempty"`
+	FlockID    *int             `json:"flockId,omitempty"`
+	Amount     decimal.Decimal  `json:"amount"`
+}
+
+type CreateExpenseRequest struct {
+	Category    model.ExpenseCategory      `json:"category"`
+	Description string                     `json:"description"`
+	Amount      decimal.Decimal            `json:"amount"`
+	IncurredOn  time.Time                  `json:"incurredOn"`
+	Allocations []ExpenseAllocationRequest `json:"allocations"`
+}
+
+// Validate checks the request and makes sure the allocations add up to the expense amount.
+func (r *CreateExpenseRequest) Validate() error {
+	if !r.Category.Valid() {
+		return fmt.Errorf("unknown expense category %q", r.Category)
+	}
+	if !r.Amount.IsPositive() {
+		return errors.New("amount must be greater than zero")
+	}
+	if r.IncurredOn.IsZero() {
+		return errors.New("incurredOn is required")
+	}
+	if len(r.Allocations) == 0 {
+		return errors.New("at least one allocation is required")
+	}
+
+	total := decimal.Zero
+	for _, a := range r.Allocations {
+		if !a.Enterprise.Valid() {
+			return fmt.Errorf("unknown enterprise %q", a.Enterprise)
+		}
+		if !a.Amount.IsPositive() {
+			return errors.New("allocation amount must be greater than zero")
+		}
+		total = total.Add(a.Amount)
+	}
+	if !total.Equal(r.Amount) {
+		return fmt.Errorf("allocations total %s does not match expense amount %s", total, r.Amount)
+	}
+	return nil
+}
+
+func (r *CreateExpenseRequest) ToModel() model.Expense {
+	allocations := make([]model.ExpenseAllocation, 0, len(r.Allocations))
+	for _, a := range r.Allocations {
+		allocations = append(allocations, model.ExpenseAllocation{
+			Enterprise: a.Enterprise,
+			PenID:      a.PenID,
+			FlockID:    a.FlockID,
+			Amount:     a.Amount,
+		})
+	}
+	return model.Expense{
+		Category:    r.Category,
+		Description: r.Description,
+		Amount:      r.Amount,
+		IncurredOn:  r.IncurredOn,
+		Allocations: allocations,
+	}
+}
+
+type ExpenseAllocationResponse struct {
+	Enterprise model.Enterprise `json:"enterprise"`
+	PenID      *int             `json:"penId,omitempty"`
+	FlockID    *int             `json:"flockId,omitempty"`
+	Amount     decimal.Decimal  `json:"amount"`
+}
+
+type ExpenseResponse struct {
+	ID          int                         `json:"id"`
+	Category    model.ExpenseCategory       `json:"category"`
+	Description string                      `json:"description"`
+	Amount      decimal.Decimal             `json:"amount"`
+	IncurredOn  time.Time                   `json:"incurredOn"`
+	Allocations []ExpenseAllocationResponse `json:"allocations"`
+}
+
+func NewExpenseResponse(e model.Expense) ExpenseResponse {
+	allocations := make([]ExpenseAllocationResponse, 0, len(e.Allocations))
+	for _, a := range e.Allocations {
+		allocations = append(allocations, ExpenseAllocationResponse{
+			Enterprise: a.Enterprise,
+			PenID:      a.PenID,
+			FlockID:    a.FlockID,
+			Amount:     a.Amount,
+		})
+	}
+	return ExpenseResponse{
+		ID:          e.ID,
+		Category:    e.Category,
+		Description: e.Description,
+		Amount:      e.Amount,
+		IncurredOn:  e.IncurredOn,
+		Allocations: allocations,
+	}
+}
+
+type CreateIncomeRequest struct {
+	Enterprise  model.Enterprise `json:"enterprise"`
+	Description string           `json:"description"`
+	Amount      decimal.Decimal  `json:"amount"`
+	ReceivedOn  time.Time        `json:"receivedOn"`
+}
+
+func (r *CreateIncomeRequest) Validate() error {
+	if !r.Enterprise.Valid() {
+		return fmt.Errorf("unknown enterprise %q", r.Enterprise)
+	}
+	if !r.Amount.IsPositive() {
+		return errors.New("amount must be greater than zero")
+	}
+	if r.ReceivedOn.IsZero() {
+		return errors.New("receivedOn is required")
+	}
+	return nil
+}
+
+func (r *CreateIncomeRequest) ToModel() model.Income {
+	return model.Income{
+		Enterprise:  r.Enterprise,
+		Description: r.Description,
+		Amount:      r.Amount,
+		ReceivedOn:  r.ReceivedOn,
+	}
+}
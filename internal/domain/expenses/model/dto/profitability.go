@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model"
+	"github.com/shopspring/decimal"
+)
+
+type ProfitabilityRequest struct {
+	From   time.Time          `form:"from" time_format:"2006-01-02"`
+	To     time.Time          `form:"to" time_format:"2006-01-02"`
+	Period model.ReportPeriod `form:"period"`
+}
+
+// Validate checks the requested range and defaults the period to month.
+func (r *ProfitabilityRequest) Validate() error {
+	if r.Period == "" {
+		r.Period = model.ReportPeriodMonth
+	}
+	if !r.Period.Valid() {
+		return fmt.Errorf("unknown period %q", r.Period)
+	}
+	if r.From.IsZero() || r.To.IsZero() {
+		return errors.New("from and to are required")
+	}
+	if !r.To.After(r.From) {
+		return errors.New("to must be after from")
+	}
+	return nil
+}
+
+// EnterpriseTotals sums income, expense and profit of one enterprise over the whole range.
+type EnterpriseTotals struct {
+	Income  decimal.Decimal `json:"income"`
+	Expense decimal.Decimal `json:"expense"`
+	Profit  decimal.Decimal `json:"profit"`
+}
+
+type ProfitabilityMetadata struct {
+	From   time.Time                             `json:"from"`
+	To     time.Time                             `json:"to"`
+	Period model.ReportPeriod                    `json:"period"`
+	Totals map[model.Enterprise]EnterpriseTotals `json:"totals"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExpenseCategory classifies what an expense was spent on.
+type ExpenseCategory string
+
+const (
+	ExpenseCategoryFeed      ExpenseCategory = "feed"
+	ExpenseCategoryVet       ExpenseCategory = "vet"
+	ExpenseCategoryLabour    ExpenseCategory = "labour"
+	ExpenseCategoryUtilities ExpenseCategory = "utilities"
+	ExpenseCategoryCapex     ExpenseCategory = "capex"
+)
+
+// Valid reports whether the category is one of the known categories.
+func (c ExpenseCategory) Valid() bool {
+	switch c {
+	case ExpenseCategoryFeed, ExpenseCategoryVet, ExpenseCategoryLabour, ExpenseCategoryUtilities, ExpenseCategoryCapex:
+		return true
+	}
+	return false
+}
+
+// Enterprise is a farm business line that income and costs are accounted to.
+type Enterprise string
+
+const (
+	EnterpriseDairy   Enterprise = "dairy"
+	EnterprisePoultry Enterprise = "poultry"
+	EnterpriseCrops   Enterprise = "crops"
+)
+
+// Valid reports whether the enterprise is one of the known enterprises.
+func (e Enterprise) Valid() bool {
+	switch e {
+	case EnterpriseDairy, EnterprisePoultry, EnterpriseCrops:
+		return true
+	}
+	return false
+}
+
+type Expense struct {
+	ID          int                 `db:"id"`
+	Category    ExpenseCategory     `db:"category"`
+	Description string              `db:"description"`
+	Amount      decimal.Decimal     `db:"amount"`
+	IncurredOn  time.Time           `db:"incurred_on"`
+	CreatedAt   time.Time           `db:"created_at"`
+	Allocations []ExpenseAllocation `db:"-"`
+}
+
+// ExpenseAllocation assigns part of an expense to an enterprise and,
+// optionally, to a single pen or flock within it.
+type ExpenseAllocation struct {
+	ID         int             `db:"id"`
+	ExpenseID  int             `db:"expense_id"`
+	Enterprise Enterprise      `db:"enterprise"`
+	PenID      *int            `db:"pen_id"`
+	FlockID    *int            `db:"flock_id"`
+	Amount     decimal.Decimal `db:"amount"`
+}
+
+type Income struct {
+	ID          int             `db:"id"`
+	Enterprise  Enterprise      `db:"enterprise"`
+	Description string          `db:"description"`
+	Amount      decimal.Decimal `db:"amount"`
+	ReceivedOn  time.Time       `db:"received_on"`
+	CreatedAt   time.Time       `db:"created_at"`
+}
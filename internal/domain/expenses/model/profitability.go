@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReportPeriod is the granularity used to bucket a profitability report.
+type ReportPeriod string
+
+const (
+	ReportPeriodMonth   ReportPeriod = "month"
+	ReportPeriodQuarter ReportPeriod = "quarter"
+	ReportPeriodYear    ReportPeriod = "year"
+)
+
+// Valid reports whether the period is supported by the report query.
+func (p ReportPeriod) Valid() bool {
+	switch p {
+	case ReportPeriodMonth, ReportPeriodQuarter, ReportPeriodYear:
+		return true
+	}
+	return false
+}
+
+// ProfitabilityRow holds income and expense totals of one enterprise in one period.
+type ProfitabilityRow struct {
+	Enterprise  Enterprise      `db:"enterprise" json:"enterprise"`
+	PeriodStart time.Time       `db:"period_start" json:"periodStart"`
+	Income      decimal.Decimal `db:"income" json:"income"`
+	Expense     decimal.Decimal `db:"expense" json:"expense"`
+	Profit      decimal.Decimal `db:"-" json:"profit"`
+}
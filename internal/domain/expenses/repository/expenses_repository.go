@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var (
+	createExpense = struct {
+		Query           string
+		AllocationQuery string
+	}{
+		Query: `INSERT INTO expenses (category, description, amount, incurred_on)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at`,
+		AllocationQuery: `INSERT INTO expense_allocations (expense_id, enterprise, pen_id, flock_id, amount)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`,
+	}
+
+	getExpense = struct {
+		Query           string
+		AllocationQuery string
+	}{
+		Query: `SELECT id, category, description, amount, incurred_on, created_at
+			FROM expenses
+			WHERE id = $1`,
+		AllocationQuery: `SELECT id, expense_id, enterprise, pen_id, flock_id, amount
+			FROM expense_allocations
+			WHERE expense_id = $1
+			ORDER BY id`,
+	}
+)
+
+type ExpenseRepository interface {
+	CreateExpense(ctx context.Context, expense *model.Expense) error
+	GetExpense(ctx context.Context, id int) (model.Expense, error)
+}
+
+// CreateExpense stores the expense together with its allocations in a single transaction.
+func (r *ExpensesRepositoryImpl) CreateExpense(ctx context.Context, expense *model.Expense) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		err := tx.QueryRowxContext(ctx, createExpense.Query,
+			expense.Category,
+			expense.Description,
+			expense.Amount,
+			expense.IncurredOn,
+		).Scan(&expense.ID, &expense.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		for i := range expense.Allocations {
+			allocation := &expense.Allocations[i]
+			allocation.ExpenseID = expense.ID
+			err = tx.QueryRowxContext(ctx, createExpense.AllocationQuery,
+				allocation.ExpenseID,
+				allocation.Enterprise,
+				allocation.PenID,
+				allocation.FlockID,
+				allocation.Amount,
+			).Scan(&allocation.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditCreate,
+			EntityType: "expense",
+			EntityID:   expense.ID,
+			After:      expense,
+		})
+	})
+}
+
+func (r *ExpensesRepositoryImpl) GetExpense(ctx context.Context, id int) (model.Expense, error) {
+	var expense model.Expense
+	err := r.DB.Reader(ctx).GetContext(ctx, &expense, getExpense.Query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return expense, failure.NotFound("expense")
+		}
+		return expense, err
+	}
+
+	err = r.DB.Reader(ctx).SelectContext(ctx, &expense.Allocations, getExpense.AllocationQuery, id)
+	return expense, err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model"
+)
+
+var (
+	createIncome = struct {
+		Query string
+	}{
+		Query: `INSERT INTO incomes (enterprise, description, amount, received_on)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at`,
+	}
+)
+
+type IncomeRepository interface {
+	CreateIncome(ctx context.Context, income *model.Income) error
+}
+
+func (r *ExpensesRepositoryImpl) CreateIncome(ctx context.Context, income *model.Income) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		err := tx.QueryRowxContext(ctx, createIncome.Query,
+			income.Enterprise,
+			income.Description,
+			income.Amount,
+			income.ReceivedOn,
+		).Scan(&income.ID, &income.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditCreate,
+			EntityType: "income",
+			EntityID:   income.ID,
+			After:      income,
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model"
+)
+
+var (
+	getProfitability = struct {
+		Query string
+	}{
+		Query: `WITH income_totals AS (
+				SELECT enterprise, date_trunc($1, received_on) AS period_start, SUM(amount) AS total
+				FROM incomes
+				WHERE received_on >= $2 AND received_on < $3
+				GROUP BY 1, 2
+			), expense_totals AS (
+				SELECT a.enterprise, date_trunc($1, e.incurred_on) AS period_start, SUM(a.amount) AS total
+				FROM expense_allocations a
+				JOIN expenses e ON e.id = a.expense_id
+				WHERE e.incurred_on >= $2 AND e.incurred_on < $3
+				GROUP BY 1, 2
+			)
+			SELECT
+				COALESCE(i.enterprise, x.enterprise) AS enterprise,
+				COALESCE(i.period_start, x.period_start) AS period_start,
+				COALESCE(i.total, 0) AS income,
+				COALESCE(x.total, 0) AS expense
+			FROM income_totals i
+			FULL OUTER JOIN expense_totals x
+				ON x.enterprise = i.enterprise AND x.period_start = i.period_start
+			ORDER BY period_start, enterprise`,
+	}
+)
+
+type ProfitabilityRepository interface {
+	GetProfitability(ctx context.Context, period model.ReportPeriod, from, to time.Time) ([]model.ProfitabilityRow, error)
+}
+
+func (r *ExpensesRepositoryImpl) GetProfitability(ctx context.Context, period model.ReportPeriod, from, to time.Time) ([]model.ProfitabilityRow, error) {
+	rows := []model.ProfitabilityRow{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &rows, getProfitability.Query, string(period), from, to)
+	return rows, err
+}
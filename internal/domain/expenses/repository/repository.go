@@ -0,0 +1,20 @@
+package repository
+
+import "github.com/sanika-farm/sanika-farm-be/infras"
+
+// ExpensesRepository is the interface for repository.
+type ExpensesRepository interface {
+	ExpenseRepository
+	IncomeRepository
+	ProfitabilityRepository
+}
+
+type ExpensesRepositoryImpl struct {
+	DB *infras.PostgresConn
+}
+
+func ProvideExpensesRepository(db *infras.PostgresConn) *ExpensesRepositoryImpl {
+	return &ExpensesRepositoryImpl{
+		DB: db,
+	}
+}
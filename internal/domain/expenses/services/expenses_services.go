@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type ExpenseService interface {
+	CreateExpense(ctx context.Context, req *dto.CreateExpenseRequest) (dto.ExpenseResponse, error)
+	GetExpense(ctx context.Context, id int) (dto.ExpenseResponse, error)
+}
+
+func (s ExpensesServiceImpl) CreateExpense(ctx context.Context, req *dto.CreateExpenseRequest) (dto.ExpenseResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.ExpenseResponse{}, failure.BadRequest(err)
+	}
+
+	expense := req.ToModel()
+	err := s.ExpensesRepository.CreateExpense(ctx, &expense)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to create expense")
+		return dto.ExpenseResponse{}, failure.InternalError(err)
+	}
+	return dto.NewExpenseResponse(expense), nil
+}
+
+func (s ExpensesServiceImpl) GetExpense(ctx context.Context, id int) (dto.ExpenseResponse, error) {
+	expense, err := s.ExpensesRepository.GetExpense(ctx, id)
+	if err != nil {
+		if failure.GetCode(err) >= 500 {
+			logger.FromContext(ctx).Error().Err(err).Int("id", id).Msg("Failed to get expense")
+			return dto.ExpenseResponse{}, failure.InternalError(err)
+		}
+		return dto.ExpenseResponse{}, err
+	}
+	return dto.NewExpenseResponse(expense), nil
+}
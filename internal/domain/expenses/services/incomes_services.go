@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type IncomeService interface {
+	CreateIncome(ctx context.Context, req *dto.CreateIncomeRequest) (model.Income, error)
+}
+
+func (s ExpensesServiceImpl) CreateIncome(ctx context.Context, req *dto.CreateIncomeRequest) (model.Income, error) {
+	if err := req.Validate(); err != nil {
+		return model.Income{}, failure.BadRequest(err)
+	}
+
+	income := req.ToModel()
+	err := s.ExpensesRepository.CreateIncome(ctx, &income)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to create income")
+		return model.Income{}, failure.InternalError(err)
+	}
+	return income, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type ProfitabilityService interface {
+	GetProfitability(ctx context.Context, req *dto.ProfitabilityRequest) ([]model.ProfitabilityRow, dto.ProfitabilityMetadata, error)
+}
+
+// GetProfitability returns income, expense and profit per enterprise and period,
+// along with totals per enterprise over the whole requested range.
+func (s ExpensesServiceImpl) GetProfitability(ctx context.Context, req *dto.ProfitabilityRequest) ([]model.ProfitabilityRow, dto.ProfitabilityMetadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, dto.ProfitabilityMetadata{}, failure.BadRequest(err)
+	}
+
+	rows, err := s.ExpensesRepository.GetProfitability(ctx, req.Period, req.From, req.To)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to get profitability report")
+		return nil, dto.ProfitabilityMetadata{}, failure.InternalError(err)
+	}
+
+	totals := make(map[model.Enterprise]dto.EnterpriseTotals)
+	for i := range rows {
+		rows[i].Profit = rows[i].Income.Sub(rows[i].Expense)
+
+		t := totals[rows[i].Enterprise]
+		t.Income = t.Income.Add(rows[i].Income)
+		t.Expense = t.Expense.Add(rows[i].Expense)
+		t.Profit = t.Income.Sub(t.Expense)
+		totals[rows[i].Enterprise] = t
+	}
+
+	return rows, dto.ProfitabilityMetadata{
+		From:   req.From,
+		To:     req.To,
+		Period: req.Period,
+		Totals: totals,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/repository"
+)
+
+type ExpensesService interface {
+	ExpenseService
+	IncomeService
+	ProfitabilityService
+}
+
+type ExpensesServiceImpl struct {
+	ExpensesRepository repository.ExpensesRepository
+	cfg                *configs.Config
+}
+
+func ProvideExpensesService(repo repository.ExpensesRepository, cfg *configs.Config) *ExpensesServiceImpl {
+	return &ExpensesServiceImpl{
+		ExpensesRepository: repo,
+		cfg:                cfg,
+	}
+}
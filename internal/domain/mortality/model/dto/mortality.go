@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model"
+)
+
+type RecordMortalityRequest struct {
+	Type           model.RecordType     `json:"type"`
+	OccurredOn     time.Time            `json:"occurredOn"`
+	CauseCategory  model.CauseCategory  `json:"causeCategory"`
+	Note           string               `json:"note"`
+	DisposalMethod model.DisposalMethod `json:"disposalMethod"`
+	FoundByUserID  *int                 `json:"foundByUserId,omitempty"`
+}
+
+func (r *RecordMortalityRequest) Validate() error {
+	if !r.Type.Valid() {
+		return fmt.Errorf("unknown record type %q", r.Type)
+	}
+	if r.OccurredOn.IsZero() {
+		return errors.New("occurredOn is required")
+	}
+	if r.OccurredOn.After(time.Now()) {
+		return errors.New("occurredOn must not be in the future")
+	}
+	if !r.CauseCategory.Valid() {
+		return fmt.Errorf("unknown cause category %q", r.CauseCategory)
+	}
+	if !r.DisposalMethod.Valid() {
+		return fmt.Errorf("unknown disposal method %q", r.DisposalMethod)
+	}
+	return nil
+}
+
+func (r *RecordMortalityRequest) ToModel(animalID int) model.MortalityRecord {
+	return model.MortalityRecord{
+		AnimalID:       animalID,
+		Type:           r.Type,
+		OccurredOn:     r.OccurredOn,
+		CauseCategory:  r.CauseCategory,
+		Note:           r.Note,
+		DisposalMethod: r.DisposalMethod,
+		FoundByUserID:  r.FoundByUserID,
+	}
+}
+
+type MortalityRecordResponse struct {
+	ID             int                  `json:"id"`
+	AnimalID       int                  `json:"animalId"`
+	Type           model.RecordType     `json:"type"`
+	OccurredOn     time.Time            `json:"occurredOn"`
+	CauseCategory  model.CauseCategory  `json:"causeCategory"`
+	Note           string               `json:"note"`
+	DisposalMethod model.DisposalMethod `json:"disposalMethod"`
+	FoundByUserID  *int                 `json:"foundByUserId,omitempty"`
+}
+
+func NewMortalityRecordResponse(m model.MortalityRecord) MortalityRecordResponse {
+	return MortalityRecordResponse{
+		ID:             m.ID,
+		AnimalID:       m.AnimalID,
+		Type:           m.Type,
+		OccurredOn:     m.OccurredOn,
+		CauseCategory:  m.CauseCategory,
+		Note:           m.Note,
+		DisposalMethod: m.DisposalMethod,
+		FoundByUserID:  m.FoundByUserID,
+	}
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+type MortalityReportRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+func (r *MortalityReportRequest) Validate() error {
+	if r.From.IsZero() || r.To.IsZero() {
+		return errors.New("from and to are required")
+	}
+	if !r.To.After(r.From) {
+		return errors.New("to must be after from")
+	}
+	return nil
+}
+
+// MortalityRate is the number of deaths in a group and the rate per 100 head at risk.
+type MortalityRate struct {
+	Key   string  `json:"key"`
+	Count int     `json:"count"`
+	Rate  float64 `json:"rate"`
+}
+
+// MortalityReport breaks mortality down by cause, pen, age band and month.
+// Rates by pen use the pen's own population at risk, every other breakdown
+// uses the population of the whole farm.
+type MortalityReport struct {
+	From       time.Time       `json:"from"`
+	To         time.Time       `json:"to"`
+	Population int             `json:"population"`
+	Total      MortalityRate   `json:"total"`
+	ByCause    []MortalityRate `json:"byCause"`
+	ByPen      []MortalityRate `json:"byPen"`
+	ByAgeBand  []MortalityRate `json:"byAgeBand"`
+	ByMonth    []MortalityRate `json:"byMonth"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// MortalityRecorded is published when an animal is recorded as dead or culled.
+type MortalityRecorded struct {
+	RecordID      int           `json:"recordId"`
+	AnimalID      int           `json:"animalId"`
+	Type          RecordType    `json:"type"`
+	CauseCategory CauseCategory `json:"causeCategory"`
+	OccurredOn    time.Time     `json:"occurredOn"`
+}
+
+func (MortalityRecorded) EventName() string { return "mortality.recorded" }
@@ -0,0 +1,87 @@
+package model
+
+import "time"
+
+// RecordType tells whether an animal died on its own or was culled.
+type RecordType string
+
+const (
+	RecordTypeDeath RecordType = "death"
+	RecordTypeCull  RecordType = "cull"
+)
+
+// Valid reports whether the record type is known.
+func (t RecordType) Valid() bool {
+	return t == RecordTypeDeath || t == RecordTypeCull
+}
+
+// AnimalStatus returns the status an animal moves to after this kind of record.
+func (t RecordType) AnimalStatus() string {
+	if t == RecordTypeCull {
+		return AnimalStatusCulled
+	}
+	return AnimalStatusDead
+}
+
+// CauseCategory groups causes of death for outbreak analysis.
+type CauseCategory string
+
+const (
+	CauseCategoryDisease           CauseCategory = "disease"
+	CauseCategoryInjury            CauseCategory = "injury"
+	CauseCategoryPredation         CauseCategory = "predation"
+	CauseCategoryBirthComplication CauseCategory = "birth_complication"
+	CauseCategoryPoisoning         CauseCategory = "poisoning"
+	CauseCategoryOldAge            CauseCategory = "old_age"
+	CauseCategoryLowProductivity   CauseCategory = "low_productivity"
+	CauseCategoryUnknown           CauseCategory = "unknown"
+)
+
+// Valid reports whether the cause category is known.
+func (c CauseCategory) Valid() bool {
+	switch c {
+	case CauseCategoryDisease, CauseCategoryInjury, CauseCategoryPredation, CauseCategoryBirthComplication,
+		CauseCategoryPoisoning, CauseCategoryOldAge, CauseCategoryLowProductivity, CauseCategoryUnknown:
+		return true
+	}
+	return false
+}
+
+// DisposalMethod is how the carcass was disposed of.
+type DisposalMethod string
+
+const (
+	DisposalMethodBurial       DisposalMethod = "burial"
+	DisposalMethodIncineration DisposalMethod = "incineration"
+	DisposalMethodRendering    DisposalMethod = "rendering"
+	DisposalMethodComposting   DisposalMethod = "composting"
+	DisposalMethodSlaughter    DisposalMethod = "slaughter"
+)
+
+// Valid reports whether the disposal method is known.
+func (d DisposalMethod) Valid() bool {
+	switch d {
+	case DisposalMethodBurial, DisposalMethodIncineration, DisposalMethodRendering, DisposalMethodComposting, DisposalMethodSlaughter:
+		return true
+	}
+	return false
+}
+
+// Animal statuses read and written by this domain.
+const (
+	AnimalStatusActive = "active"
+	AnimalStatusDead   = "dead"
+	AnimalStatusCulled = "culled"
+)
+
+type MortalityRecord struct {
+	ID             int            `db:"id"`
+	AnimalID       int            `db:"animal_id"`
+	Type           RecordType     `db:"type"`
+	OccurredOn     time.Time      `db:"occurred_on"`
+	CauseCategory  CauseCategory  `db:"cause_category"`
+	Note           string         `db:"note"`
+	DisposalMethod DisposalMethod `db:"disposal_method"`
+	FoundByUserID  *int           `db:"found_by_user_id"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// MortalityCount is the number of deaths sharing one month, pen, cause and age band.
+type MortalityCount struct {
+	Month         time.Time     `db:"month"`
+	PenID         *int          `db:"pen_id"`
+	CauseCategory CauseCategory `db:"cause_category"`
+	AgeBand       string        `db:"age_band"`
+	Count         int           `db:"count"`
+}
+
+// PenPopulation is the number of animals at risk in a pen over a report range.
+type PenPopulation struct {
+	PenID      *int `db:"pen_id"`
+	Population int  `db:"population"`
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var (
+	recordMortality = struct {
+		AnimalStatusQuery string
+		Query             string
+		UpdateAnimal      string
+	}{
+		AnimalStatusQuery: `SELECT status FROM animals WHERE id = $1 FOR UPDATE`,
+		Query: `INSERT INTO mortality_records (animal_id, type, occurred_on, cause_category, note, disposal_method, found_by_user_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at`,
+		UpdateAnimal: `UPDATE animals SET status = $2, updated_at = NOW() WHERE id = $1`,
+	}
+)
+
+type MortalityRecordRepository interface {
+	RecordMortality(ctx context.Context, record *model.MortalityRecord) error
+}
+
+// RecordMortality stores a death or cull and marks the animal as dead or
+// culled in the same transaction. Animals that are no longer active are
+// rejected with a conflict.
+func (r *MortalityRepositoryImpl) RecordMortality(ctx context.Context, record *model.MortalityRecord) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		var status string
+		err := tx.GetContext(ctx, &status, recordMortality.AnimalStatusQuery, record.AnimalID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return failure.NotFound("animal")
+			}
+			return err
+		}
+		if status != model.AnimalStatusActive {
+			return failure.Conflict("record mortality", "animal", fmt.Sprintf("animal is already %s", status))
+		}
+
+		err = tx.QueryRowxContext(ctx, recordMortality.Query,
+			record.AnimalID,
+			record.Type,
+			record.OccurredOn,
+			record.CauseCategory,
+			record.Note,
+			record.DisposalMethod,
+			record.FoundByUserID,
+		).Scan(&record.ID, &record.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, recordMortality.UpdateAnimal, record.AnimalID, record.Type.AnimalStatus())
+		if err != nil {
+			return err
+		}
+
+		err = infras.RecordAudit(ctx, tx,
+			infras.AuditChange{
+				Action:     infras.AuditCreate,
+				EntityType: "mortality_record",
+				EntityID:   record.ID,
+				After:      record,
+			},
+			infras.AuditChange{
+				Action:     infras.AuditUpdate,
+				EntityType: "animal",
+				EntityID:   record.AnimalID,
+				Before:     map[string]string{"status": status},
+				After:      map[string]string{"status": record.Type.AnimalStatus()},
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		return infras.PublishEvents(ctx, tx, model.MortalityRecorded{
+			RecordID:      record.ID,
+			AnimalID:      record.AnimalID,
+			Type:          record.Type,
+			CauseCategory: record.CauseCategory,
+			OccurredOn:    record.OccurredOn,
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model"
+)
+
+var (
+	getMortalityReport = struct {
+		CountsQuery     string
+		PopulationQuery string
+		SnapshotQuery   string
+	}{
+		CountsQuery: `SELECT
+				date_trunc('month', m.occurred_on) AS month,
+				a.pen_id,
+				m.cause_category,
+				CASE
+					WHEN a.birth_date IS NULL THEN 'unknown'
+					WHEN age(m.occurred_on, a.birth_date) < INTERVAL '1 month' THEN '0-1m'
+					WHEN age(m.occurred_on, a.birth_date) < INTERVAL '6 months' THEN '1-6m'
+					WHEN age(m.occurred_on, a.birth_date) < INTERVAL '1 year' THEN '6-12m'
+					WHEN age(m.occurred_on, a.birth_date) < INTERVAL '2 years' THEN '1-2y'
+					ELSE '2y+'
+				END AS age_band,
+				COUNT(*) AS count
+			FROM mortality_records m
+			JOIN animals a ON a.id = m.animal_id
+			WHERE m.occurred_on >= $1 AND m.occurred_on < $2
+			GROUP BY 1, 2, 3, 4
+			ORDER BY 1, 2, 3, 4`,
+		// Animals at risk are those born before the end of the range that
+		// were still active, or left the herd through death or culling
+		// within or after the range.
+		PopulationQuery: `SELECT a.pen_id, COUNT(*) AS population
+			FROM animals a
+			WHERE (a.birth_date IS NULL OR a.birth_date < $2)
+				AND (
+					a.status = 'active'
+					OR EXISTS (
+						SELECT 1 FROM mortality_records m
+						WHERE m.animal_id = a.id AND m.occurred_on >= $1
+					)
+				)
+			GROUP BY a.pen_id`,
+		SnapshotQuery: `INSERT INTO report_snapshots (report, period_start, period_end, data)
+			VALUES ('mortality', $1, $2, $3)
+			ON CONFLICT (report, period_start, period_end)
+			DO UPDATE SET data = EXCLUDED.data, created_at = NOW()`,
+	}
+)
+
+type MortalityReportRepository interface {
+	GetMortalityCounts(ctx context.Context, from, to time.Time) ([]model.MortalityCount, error)
+	GetPopulation(ctx context.Context, from, to time.Time) ([]model.PenPopulation, error)
+	SaveReportSnapshot(ctx context.Context, from, to time.Time, data []byte) error
+}
+
+func (r *MortalityRepositoryImpl) GetMortalityCounts(ctx context.Context, from, to time.Time) ([]model.MortalityCount, error) {
+	counts := []model.MortalityCount{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &counts, getMortalityReport.CountsQuery, from, to)
+	return counts, err
+}
+
+func (r *MortalityRepositoryImpl) GetPopulation(ctx context.Context, from, to time.Time) ([]model.PenPopulation, error) {
+	population := []model.PenPopulation{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &population, getMortalityReport.PopulationQuery, from, to)
+	return population, err
+}
+
+// SaveReportSnapshot stores a computed report for the range, replacing an
+// earlier snapshot of the same range.
+func (r *MortalityRepositoryImpl) SaveReportSnapshot(ctx context.Context, from, to time.Time, data []byte) error {
+	_, err := r.DB.Writer(ctx).ExecContext(ctx, getMortalityReport.SnapshotQuery, from, to, string(data))
+	return err
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/sanika-farm/sanika-farm-be/infras"
+
+// MortalityRepository is the interface for repository.
+type MortalityRepository interface {
+	MortalityRecordRepository
+	MortalityReportRepository
+}
+
+type MortalityRepositoryImpl struct {
+	DB *infras.PostgresConn
+}
+
+func ProvideMortalityRepository(db *infras.PostgresConn) *MortalityRepositoryImpl {
+	return &MortalityRepositoryImpl{
+		DB: db,
+	}
+}
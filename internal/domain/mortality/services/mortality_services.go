@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type MortalityRecordService interface {
+	RecordMortality(ctx context.Context, animalID int, req *dto.RecordMortalityRequest) (dto.MortalityRecordResponse, error)
+}
+
+func (s MortalityServiceImpl) RecordMortality(ctx context.Context, animalID int, req *dto.RecordMortalityRequest) (dto.MortalityRecordResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.MortalityRecordResponse{}, failure.BadRequest(err)
+	}
+
+	record := req.ToModel(animalID)
+	err := s.MortalityRepository.RecordMortality(ctx, &record)
+	if err != nil {
+		if failure.GetCode(err) >= 500 {
+			logger.FromContext(ctx).Error().Err(err).Int("animalId", animalID).Msg("Failed to record mortality")
+			return dto.MortalityRecordResponse{}, failure.InternalError(err)
+		}
+		logger.FromContext(ctx).Warn().Err(err).Int("animalId", animalID).Msg("Failed to record mortality")
+		return dto.MortalityRecordResponse{}, err
+	}
+	s.recordsTotal.Inc(string(record.Type), string(record.CauseCategory))
+	return dto.NewMortalityRecordResponse(record), nil
+}
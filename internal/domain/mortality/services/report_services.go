@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+const noPenKey = "none"
+
+type MortalityReportService interface {
+	GetMortalityReport(ctx context.Context, req *dto.MortalityReportRequest) (dto.MortalityReport, error)
+}
+
+// GetMortalityReport counts deaths and culls in the requested range and
+// expresses them per 100 head at risk, broken down by cause, pen, age band
+// and month.
+func (s MortalityServiceImpl) GetMortalityReport(ctx context.Context, req *dto.MortalityReportRequest) (dto.MortalityReport, error) {
+	if err := req.Validate(); err != nil {
+		return dto.MortalityReport{}, failure.BadRequest(err)
+	}
+
+	counts, err := s.MortalityRepository.GetMortalityCounts(ctx, req.From, req.To)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to get mortality counts")
+		return dto.MortalityReport{}, failure.InternalError(err)
+	}
+	population, err := s.MortalityRepository.GetPopulation(ctx, req.From, req.To)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to get population at risk")
+		return dto.MortalityReport{}, failure.InternalError(err)
+	}
+
+	total := 0
+	penPopulation := make(map[string]int, len(population))
+	for _, p := range population {
+		penPopulation[penKey(p.PenID)] = p.Population
+		total += p.Population
+	}
+
+	var deaths int
+	byCause := map[string]int{}
+	byPen := map[string]int{}
+	byAgeBand := map[string]int{}
+	byMonth := map[string]int{}
+	for _, c := range counts {
+		deaths += c.Count
+		byCause[string(c.CauseCategory)] += c.Count
+		byPen[penKey(c.PenID)] += c.Count
+		byAgeBand[c.AgeBand] += c.Count
+		byMonth[c.Month.Format("2006-01")] += c.Count
+	}
+
+	return dto.MortalityReport{
+		From:       req.From,
+		To:         req.To,
+		Population: total,
+		Total:      dto.MortalityRate{Key: "total", Count: deaths, Rate: rate(deaths, total)},
+		ByCause:    toRates(byCause, func(string) int { return total }),
+		ByPen:      toRates(byPen, func(key string) int { return penPopulation[key] }),
+		ByAgeBand:  toRates(byAgeBand, func(string) int { return total }),
+		ByMonth:    toRates(byMonth, func(string) int { return total }),
+	}, nil
+}
+
+// snapshotMortalityReport stores the report of the day before scheduledAt,
+// so daily figures stay as they were even if records are corrected later.
+func (s MortalityServiceImpl) snapshotMortalityReport(ctx context.Context, scheduledAt time.Time) error {
+	to := time.Date(scheduledAt.Year(), scheduledAt.Month(), scheduledAt.Day(), 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -1)
+
+	report, err := s.GetMortalityReport(ctx, &dto.MortalityReportRequest{From: from, To: to})
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return s.MortalityRepository.SaveReportSnapshot(ctx, from, to, data)
+}
+
+func penKey(penID *int) string {
+	if penID == nil {
+		return noPenKey
+	}
+	return strconv.Itoa(*penID)
+}
+
+func toRates(counts map[string]int, population func(key string) int) []dto.MortalityRate {
+	rates := make([]dto.MortalityRate, 0, len(counts))
+	for key, count := range counts {
+		rates = append(rates, dto.MortalityRate{
+			Key:   key,
+			Count: count,
+			Rate:  rate(count, population(key)),
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Key < rates[j].Key })
+	return rates
+}
+
+// rate returns deaths per 100 head, rounded to two decimals.
+func rate(count, population int) float64 {
+	if population == 0 {
+		return 0
+	}
+	return float64(int(float64(count)*10000/float64(population)+0.5)) / 100
+}
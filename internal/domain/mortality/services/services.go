@@ -0,0 +1,40 @@
+package services
+
+import (
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+)
+
+type MortalityService interface {
+	MortalityRecordService
+	MortalityReportService
+}
+
+type MortalityServiceImpl struct {
+	MortalityRepository repository.MortalityRepository
+	cfg                 *configs.Config
+	recordsTotal        *metrics.Counter
+}
+
+func ProvideMortalityService(repo repository.MortalityRepository, cfg *configs.Config, registry *metrics.Registry) *MortalityServiceImpl {
+	return &MortalityServiceImpl{
+		MortalityRepository: repo,
+		cfg:                 cfg,
+		recordsTotal: registry.NewCounter("mortality_records_total",
+			"Total number of recorded deaths and culls.", "type", "cause"),
+	}
+}
+
+// RegisterJobs registers the nightly snapshot of the mortality report.
+func (s MortalityServiceImpl) RegisterJobs(scheduler *infras.Scheduler) {
+	scheduler.Register(infras.Job{
+		Name:     "mortality-report-snapshot",
+		Schedule: "15 0 * * *",
+		Timeout:  10 * time.Minute,
+		Run:      s.snapshotMortalityReport,
+	})
+}
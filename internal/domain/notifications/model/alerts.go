@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LowFeedAlert is the data of TypeLowFeed notifications, sent while a feed
+// stock item is below its reorder level.
+type LowFeedAlert struct {
+	StockItemID  int             `db:"id" json:"stockItemId"`
+	Feed         string          `db:"name" json:"feed"`
+	Remaining    decimal.Decimal `db:"quantity_on_hand" json:"remaining"`
+	ReorderLevel decimal.Decimal `db:"reorder_level" json:"reorderLevel"`
+	Unit         string          `db:"unit" json:"unit"`
+}
+
+// WithdrawalEndingAlert is the data of TypeWithdrawalEnding notifications,
+// sent before an animal's medicine withdrawal period ends.
+type WithdrawalEndingAlert struct {
+	TreatmentID int       `db:"id" json:"treatmentId"`
+	AnimalID    int       `db:"animal_id" json:"animalId"`
+	Treatment   string    `db:"product" json:"treatment"`
+	EndsOn      time.Time `db:"withdrawal_ends_on" json:"endsOn"`
+}
@@ -0,0 +1,163 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+// localePattern accepts language tags such as en, id or pt-BR.
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// ListNotificationsResource is the allow-list of fields the inbox can be
+// sorted and filtered by. The inbox is always limited to the current user.
+var ListNotificationsResource = pagination.Resource{
+	Columns: map[string]string{
+		"id":        "id",
+		"type":      "type",
+		"read":      "(read_at IS NOT NULL)",
+		"createdAt": "created_at",
+	},
+	Sortable: []string{"id", "createdAt"},
+	Filterable: map[string]pagination.FieldType{
+		"type":      pagination.String,
+		"read":      pagination.Bool,
+		"createdAt": pagination.Time,
+	},
+	DefaultSort: "-id",
+	CursorField: "id",
+	CursorType:  pagination.Int,
+}
+
+type NotificationResponse struct {
+	ID        int64           `json:"id"`
+	Type      model.Type      `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	Read      bool            `json:"read"`
+	ReadAt    *time.Time      `json:"readAt"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+func NewNotificationResponse(n model.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+type PreferenceRequest struct {
+	Type  model.Type `json:"type"`
+	Email bool       `json:"email"`
+	InApp bool       `json:"inApp"`
+}
+
+type UpdatePreferencesRequest struct {
+	// Email is the address notification emails are sent to. Email is not
+	// sent when it is empty.
+	Email string `json:"email"`
+	// Locale picks the language of notifications, such as en or id. The
+	// server default is used when it is empty.
+	Locale string `json:"locale"`
+	// Types sets the channels wanted per notification type. Types left out
+	// keep their current preference.
+	Types []PreferenceRequest `json:"types"`
+}
+
+func (r *UpdatePreferencesRequest) Validate() error {
+	if email := strings.TrimSpace(r.Email); email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return errors.New("email must be a plain email address")
+		}
+	}
+	if locale := strings.TrimSpace(r.Locale); locale != "" && !localePattern.MatchString(locale) {
+		return errors.New("locale must be a language tag such as en or pt-BR")
+	}
+	seen := map[model.Type]bool{}
+	for _, p := range r.Types {
+		if _, ok := model.LookupType(p.Type); !ok {
+			return fmt.Errorf("unknown notification type %q", p.Type)
+		}
+		if seen[p.Type] {
+			return fmt.Errorf("notification type %q is listed twice", p.Type)
+		}
+		seen[p.Type] = true
+	}
+	return nil
+}
+
+func (r *UpdatePreferencesRequest) ToModel(userID int) (model.Settings, []model.Preference) {
+	settings := model.Settings{UserID: userID}
+	if email := strings.TrimSpace(r.Email); email != "" {
+		settings.Email = &email
+	}
+	if locale := strings.TrimSpace(r.Locale); locale != "" {
+		settings.Locale = &locale
+	}
+
+	prefs := make([]model.Preference, 0, len(r.Types))
+	for _, p := range r.Types {
+		prefs = append(prefs, model.Preference{UserID: userID, Type: p.Type, Email: p.Email, InApp: p.InApp})
+	}
+	return settings, prefs
+}
+
+type PreferenceResponse struct {
+	Type  model.Type `json:"type"`
+	Email bool       `json:"email"`
+	InApp bool       `json:"inApp"`
+	// Default tells whether the user has not set a preference and the
+	// type's default channels apply.
+	Default bool `json:"default"`
+}
+
+type PreferencesResponse struct {
+	Email  *string              `json:"email"`
+	Locale string               `json:"locale"`
+	Types  []PreferenceResponse `json:"types"`
+}
+
+// NewPreferencesResponse lists every notification type with the user's
+// preference, or the type's defaults when they have not set one.
+func NewPreferencesResponse(settings model.Settings, prefs []model.Preference, defaultLocale string) PreferencesResponse {
+	res := PreferencesResponse{
+		Email:  settings.Email,
+		Locale: defaultLocale,
+		Types:  make([]PreferenceResponse, 0, len(model.Types)),
+	}
+	if settings.Locale != nil {
+		res.Locale = *settings.Locale
+	}
+
+	set := make(map[model.Type]model.Preference, len(prefs))
+	for _, p := range prefs {
+		set[p.Type] = p
+	}
+	for _, info := range model.Types {
+		if p, ok := set[info.Type]; ok {
+			res.Types = append(res.Types, PreferenceResponse{Type: info.Type, Email: p.Email, InApp: p.InApp})
+			continue
+		}
+		res.Types = append(res.Types, PreferenceResponse{Type: info.Type, Email: info.Email, InApp: info.InApp, Default: true})
+	}
+	return res
+}
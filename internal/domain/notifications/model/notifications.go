@@ -0,0 +1,122 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Type identifies a kind of notification. Users set their preferences per
+// type, and each type has its own templates.
+type Type string
+
+const (
+	TypeLowFeed            Type = "farm.low_feed"
+	TypeWithdrawalEnding   Type = "farm.withdrawal_ending"
+	TypeMortalityRecorded  Type = "farm.mortality_recorded"
+	TypeInvoiceDiscrepancy Type = "purchasing.invoice_discrepancy"
+)
+
+// Channel is a way of reaching a user.
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelInApp Channel = "in_app"
+)
+
+// TypeInfo describes a notification type. Email and InApp are the channels
+// used for users who have not set a preference for the type.
+type TypeInfo struct {
+	Type  Type
+	Email bool
+	InApp bool
+}
+
+// Types lists every notification type users can be sent.
+var Types = []TypeInfo{
+	{Type: TypeLowFeed, Email: true, InApp: true},
+	{Type: TypeWithdrawalEnding, Email: true, InApp: true},
+	{Type: TypeMortalityRecorded, Email: false, InApp: true},
+	{Type: TypeInvoiceDiscrepancy, Email: true, InApp: true},
+}
+
+// LookupType returns the description of t, and false if t is unknown.
+func LookupType(t Type) (TypeInfo, bool) {
+	for _, info := range Types {
+		if info.Type == t {
+			return info, true
+		}
+	}
+	return TypeInfo{}, false
+}
+
+// Message asks for a notification to be sent to every user who wants its
+// type. DedupKey identifies the occurrence, so sending the same message
+// again does not notify anyone twice. Data is passed to the templates and
+// kept with in-app notifications.
+type Message struct {
+	Type     Type
+	DedupKey string
+	Data     interface{}
+}
+
+// Recipient is a user with their contact settings and their preference for
+// one notification type. The preference is nil when the user has not set one.
+type Recipient struct {
+	UserID       int     `db:"user_id"`
+	Email        *string `db:"email"`
+	Locale       *string `db:"locale"`
+	EmailEnabled *bool   `db:"email_enabled"`
+	InAppEnabled *bool   `db:"in_app_enabled"`
+}
+
+// Wants reports whether the recipient wants notifications of the type over
+// ch, falling back to the type's default. Email also needs an address.
+func (r Recipient) Wants(info TypeInfo, ch Channel) bool {
+	switch ch {
+	case ChannelEmail:
+		if r.Email == nil || *r.Email == "" {
+			return false
+		}
+		if r.EmailEnabled != nil {
+			return *r.EmailEnabled
+		}
+		return info.Email
+	case ChannelInApp:
+		if r.InAppEnabled != nil {
+			return *r.InAppEnabled
+		}
+		return info.InApp
+	}
+	return false
+}
+
+// Notification is a notification rendered for one user. In-app
+// notifications are kept in the user's inbox.
+type Notification struct {
+	ID        int64           `db:"id"`
+	UserID    int             `db:"user_id"`
+	Type      Type            `db:"type"`
+	Title     string          `db:"title"`
+	Body      string          `db:"body"`
+	Data      json.RawMessage `db:"data"`
+	ReadAt    *time.Time      `db:"read_at"`
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// Settings are a user's contact details for notifications. Locale picks the
+// template variant, and the configured default is used when it is nil.
+type Settings struct {
+	UserID int     `db:"user_id" json:"userId"`
+	Email  *string `db:"email" json:"email"`
+	Locale *string `db:"locale" json:"locale"`
+}
+
+// Preference tells whether a user wants a type of notification on each
+// channel.
+type Preference struct {
+	UserID int  `db:"user_id" json:"userId"`
+	Type   Type `db:"type" json:"type"`
+	Email  bool `db:"email" json:"email"`
+	InApp  bool `db:"in_app" json:"inApp"`
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+)
+
+var (
+	listLowFeed = struct {
+		Query string
+	}{
+		Query: `SELECT id, name, quantity_on_hand, reorder_level, unit
+			FROM stock_items
+			WHERE category = 'feed' AND reorder_level IS NOT NULL AND quantity_on_hand < reorder_level
+			ORDER BY id`,
+	}
+
+	listWithdrawalsEnding = struct {
+		Query string
+	}{
+		Query: `SELECT id, animal_id, product, withdrawal_ends_on
+			FROM treatments
+			WHERE withdrawal_ends_on = $1
+			ORDER BY id`,
+	}
+)
+
+type AlertRepository interface {
+	ListLowFeed(ctx context.Context) ([]model.LowFeedAlert, error)
+	ListWithdrawalsEnding(ctx context.Context, on time.Time) ([]model.WithdrawalEndingAlert, error)
+}
+
+// ListLowFeed returns the feed stock items below their reorder level.
+func (r *NotificationsRepositoryImpl) ListLowFeed(ctx context.Context) ([]model.LowFeedAlert, error) {
+	alerts := []model.LowFeedAlert{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &alerts, listLowFeed.Query)
+	return alerts, err
+}
+
+// ListWithdrawalsEnding returns the treatments whose withdrawal period ends
+// on the given day.
+func (r *NotificationsRepositoryImpl) ListWithdrawalsEnding(ctx context.Context, on time.Time) ([]model.WithdrawalEndingAlert, error) {
+	alerts := []model.WithdrawalEndingAlert{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &alerts, listWithdrawalsEnding.Query, on)
+	return alerts, err
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+)
+
+var (
+	listRecipients = struct {
+		Query string
+	}{
+		Query: `SELECT u.id AS user_id, s.email, s.locale, p.email AS email_enabled, p.in_app AS in_app_enabled
+			FROM users u
+			LEFT JOIN notification_settings s ON s.user_id = u.id
+			LEFT JOIN notification_preferences p ON p.user_id = u.id AND p.type = $1
+			ORDER BY u.id`,
+	}
+
+	// claimDelivery records an attempt to notify a user over a channel. The
+	// unique (user_id, channel, dedup_key) key makes it return no row once
+	// the notification has been sent, so a message handled twice is not
+	// sent twice.
+	claimDelivery = struct {
+		Query string
+	}{
+		Query: `INSERT INTO notification_deliveries (user_id, channel, dedup_key, attempts)
+			VALUES ($1, $2, $3, 1)
+			ON CONFLICT (user_id, channel, dedup_key) DO UPDATE
+			SET attempts = notification_deliveries.attempts + 1
+			WHERE notification_deliveries.sent_at IS NULL
+			RETURNING id`,
+	}
+
+	finishDelivery = struct {
+		SentQuery   string
+		FailedQuery string
+	}{
+		SentQuery:   `UPDATE notification_deliveries SET sent_at = NOW(), last_error = NULL WHERE id = $1`,
+		FailedQuery: `UPDATE notification_deliveries SET last_error = $2 WHERE id = $1`,
+	}
+)
+
+type DeliveryRepository interface {
+	ListRecipients(ctx context.Context, t model.Type) ([]model.Recipient, error)
+	ClaimDelivery(ctx context.Context, userID int, ch model.Channel, dedupKey string) (int64, bool, error)
+	MarkDeliverySent(ctx context.Context, id int64) error
+	MarkDeliveryFailed(ctx context.Context, id int64, lastError string) error
+}
+
+// ListRecipients returns every user with their contact settings and their
+// preference for notifications of type t.
+func (r *NotificationsRepositoryImpl) ListRecipients(ctx context.Context, t model.Type) ([]model.Recipient, error) {
+	recipients := []model.Recipient{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &recipients, listRecipients.Query, t)
+	return recipients, err
+}
+
+// ClaimDelivery records an attempt to notify the user over ch and returns
+// its ID. It returns false if the notification has already been sent.
+func (r *NotificationsRepositoryImpl) ClaimDelivery(ctx context.Context, userID int, ch model.Channel, dedupKey string) (int64, bool, error) {
+	var id int64
+	err := r.DB.Writer(ctx).QueryRowxContext(ctx, claimDelivery.Query, userID, ch, dedupKey).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+func (r *NotificationsRepositoryImpl) MarkDeliverySent(ctx context.Context, id int64) error {
+	_, err := r.DB.Writer(ctx).ExecContext(ctx, finishDelivery.SentQuery, id)
+	return err
+}
+
+func (r *NotificationsRepositoryImpl) MarkDeliveryFailed(ctx context.Context, id int64, lastError string) error {
+	_, err := r.DB.Writer(ctx).ExecContext(ctx, finishDelivery.FailedQuery, id, lastError)
+	return err
+}
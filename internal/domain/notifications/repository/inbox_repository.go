@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+const notificationColumns = `id, user_id, type, title, body, data, read_at, created_at`
+
+var (
+	createNotification = struct {
+		Query string
+	}{
+		Query: `INSERT INTO notifications (user_id, type, title, body, data)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at`,
+	}
+
+	listNotifications = struct {
+		Query      string
+		CountQuery string
+	}{
+		Query:      `SELECT ` + notificationColumns + ` FROM notifications`,
+		CountQuery: `SELECT COUNT(*) FROM notifications`,
+	}
+
+	markNotificationsRead = struct {
+		Query    string
+		AllQuery string
+	}{
+		Query: `UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+			WHERE id = $1 AND user_id = $2`,
+		AllQuery: `UPDATE notifications SET read_at = NOW()
+			WHERE user_id = $1 AND read_at IS NULL`,
+	}
+)
+
+type InboxRepository interface {
+	CreateNotification(ctx context.Context, notification *model.Notification) error
+	ListNotifications(ctx context.Context, params pagination.Params) ([]model.Notification, int, error)
+	MarkNotificationRead(ctx context.Context, userID int, id int64) error
+	MarkAllNotificationsRead(ctx context.Context, userID int) (int64, error)
+}
+
+// CreateNotification adds a notification to its user's inbox.
+func (r *NotificationsRepositoryImpl) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	return r.DB.Writer(ctx).QueryRowxContext(ctx, createNotification.Query,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Body,
+		string(notification.Data),
+	).Scan(&notification.ID, &notification.CreatedAt)
+}
+
+// ListNotifications returns one page of notifications. The total is only
+// counted for offset pagination and is zero for cursor pages.
+func (r *NotificationsRepositoryImpl) ListNotifications(ctx context.Context, params pagination.Params) ([]model.Notification, int, error) {
+	where, args := params.Where()
+	limit, limitArgs := params.LimitOffset(len(args))
+
+	notifications := []model.Notification{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &notifications, listNotifications.Query+where+params.OrderBy()+limit, append(args, limitArgs...)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if !params.IsCursor() {
+		countWhere, countArgs := params.CountWhere()
+		if err = r.DB.Reader(ctx).GetContext(ctx, &total, listNotifications.CountQuery+countWhere, countArgs...); err != nil {
+			return nil, 0, err
+		}
+	}
+	return notifications, total, nil
+}
+
+// MarkNotificationRead marks a notification in the user's inbox as read.
+// Notifications of other users are reported as not found.
+func (r *NotificationsRepositoryImpl) MarkNotificationRead(ctx context.Context, userID int, id int64) error {
+	res, err := r.DB.Writer(ctx).ExecContext(ctx, markNotificationsRead.Query, id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return failure.NotFound("notification")
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of the user as
+// read and returns how many there were.
+func (r *NotificationsRepositoryImpl) MarkAllNotificationsRead(ctx context.Context, userID int) (int64, error) {
+	res, err := r.DB.Writer(ctx).ExecContext(ctx, markNotificationsRead.AllQuery, userID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+)
+
+var (
+	getNotificationSettings = struct {
+		Query            string
+		PreferencesQuery string
+	}{
+		Query: `SELECT user_id, email, locale FROM notification_settings WHERE user_id = $1`,
+		PreferencesQuery: `SELECT user_id, type, email, in_app
+			FROM notification_preferences
+			WHERE user_id = $1
+			ORDER BY type`,
+	}
+
+	saveNotificationSettings = struct {
+		Query           string
+		PreferenceQuery string
+	}{
+		Query: `INSERT INTO notification_settings (user_id, email, locale)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id) DO UPDATE SET email = EXCLUDED.email, locale = EXCLUDED.locale, updated_at = NOW()`,
+		PreferenceQuery: `INSERT INTO notification_preferences (user_id, type, email, in_app)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, type) DO UPDATE SET email = EXCLUDED.email, in_app = EXCLUDED.in_app, updated_at = NOW()`,
+	}
+)
+
+type PreferenceRepository interface {
+	GetNotificationSettings(ctx context.Context, userID int) (model.Settings, []model.Preference, error)
+	SaveNotificationSettings(ctx context.Context, settings model.Settings, prefs []model.Preference) error
+}
+
+// GetNotificationSettings returns the user's contact settings and the
+// preferences they have set. Users without settings get empty ones.
+func (r *NotificationsRepositoryImpl) GetNotificationSettings(ctx context.Context, userID int) (model.Settings, []model.Preference, error) {
+	settings := model.Settings{UserID: userID}
+	err := r.DB.Reader(ctx).GetContext(ctx, &settings, getNotificationSettings.Query, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return settings, nil, err
+	}
+
+	prefs := []model.Preference{}
+	err = r.DB.Reader(ctx).SelectContext(ctx, &prefs, getNotificationSettings.PreferencesQuery, userID)
+	return settings, prefs, err
+}
+
+// SaveNotificationSettings stores the user's contact settings and the given
+// preferences. Preferences for types left out are kept.
+func (r *NotificationsRepositoryImpl) SaveNotificationSettings(ctx context.Context, settings model.Settings, prefs []model.Preference) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		beforeSettings, beforePrefs, err := r.GetNotificationSettings(ctx, settings.UserID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, saveNotificationSettings.Query, settings.UserID, settings.Email, settings.Locale)
+		if err != nil {
+			return err
+		}
+		for _, pref := range prefs {
+			_, err = tx.ExecContext(ctx, saveNotificationSettings.PreferenceQuery, settings.UserID, pref.Type, pref.Email, pref.InApp)
+			if err != nil {
+				return err
+			}
+		}
+
+		afterSettings, afterPrefs, err := r.GetNotificationSettings(ctx, settings.UserID)
+		if err != nil {
+			return err
+		}
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditUpdate,
+			EntityType: "notification_settings",
+			EntityID:   settings.UserID,
+			Before:     notificationSettingsAudit{Settings: beforeSettings, Preferences: beforePrefs},
+			After:      notificationSettingsAudit{Settings: afterSettings, Preferences: afterPrefs},
+		})
+	})
+}
+
+type notificationSettingsAudit struct {
+	model.Settings
+	Preferences []model.Preference `json:"preferences"`
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/sanika-farm/sanika-farm-be/infras"
+
+// NotificationsRepository is the interface for repository.
+type NotificationsRepository interface {
+	InboxRepository
+	PreferenceRepository
+	DeliveryRepository
+	AlertRepository
+}
+
+type NotificationsRepositoryImpl struct {
+	DB *infras.PostgresConn
+}
+
+func ProvideNotificationsRepository(db *infras.PostgresConn) *NotificationsRepositoryImpl {
+	return &NotificationsRepositoryImpl{
+		DB: db,
+	}
+}
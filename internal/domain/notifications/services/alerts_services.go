@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+)
+
+// RegisterJobs registers the daily checks that raise farm alerts.
+func (s NotificationsServiceImpl) RegisterJobs(scheduler *infras.Scheduler) {
+	scheduler.Register(infras.Job{
+		Name:     "notifications-low-feed",
+		Schedule: "0 6 * * *",
+		Timeout:  10 * time.Minute,
+		Run:      s.alertLowFeed,
+	})
+	scheduler.Register(infras.Job{
+		Name:     "notifications-withdrawal-ending",
+		Schedule: "0 6 * * *",
+		Timeout:  10 * time.Minute,
+		Run:      s.alertWithdrawalsEnding,
+	})
+}
+
+// alertLowFeed notifies about every feed item below its reorder level. The
+// alert is keyed by the day, so users are reminded daily until it is
+// restocked.
+func (s NotificationsServiceImpl) alertLowFeed(ctx context.Context, scheduledAt time.Time) error {
+	alerts, err := s.NotificationsRepository.ListLowFeed(ctx)
+	if err != nil {
+		return err
+	}
+	day := scheduledAt.Format("2006-01-02")
+	var errs []error
+	for _, alert := range alerts {
+		err = s.Notify(ctx, model.Message{
+			Type:     model.TypeLowFeed,
+			DedupKey: fmt.Sprintf("low_feed:%d:%s", alert.StockItemID, day),
+			Data:     alert,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stock item %d: %w", alert.StockItemID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// alertWithdrawalsEnding notifies about the withdrawal periods ending the
+// day after the job runs.
+func (s NotificationsServiceImpl) alertWithdrawalsEnding(ctx context.Context, scheduledAt time.Time) error {
+	tomorrow := time.Date(scheduledAt.Year(), scheduledAt.Month(), scheduledAt.Day()+1, 0, 0, 0, 0, time.UTC)
+	alerts, err := s.NotificationsRepository.ListWithdrawalsEnding(ctx, tomorrow)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, alert := range alerts {
+		err = s.Notify(ctx, model.Message{
+			Type:     model.TypeWithdrawalEnding,
+			DedupKey: fmt.Sprintf("withdrawal_ending:%d", alert.TreatmentID),
+			Data:     alert,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("treatment %d: %w", alert.TreatmentID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+	"github.com/shopspring/decimal"
+)
+
+// fakeAlerts serves alerts and recipients from memory and keeps claimed
+// deliveries unique per (user, channel, dedup key), like the Postgres
+// repository.
+type fakeAlerts struct {
+	repository.NotificationsRepository
+
+	lowFeed     []model.LowFeedAlert
+	withdrawals []model.WithdrawalEndingAlert
+	endingOn    time.Time
+
+	claimed map[string]bool
+	inbox   []model.Notification
+}
+
+func (f *fakeAlerts) ListLowFeed(context.Context) ([]model.LowFeedAlert, error) {
+	return f.lowFeed, nil
+}
+
+func (f *fakeAlerts) ListWithdrawalsEnding(_ context.Context, on time.Time) ([]model.WithdrawalEndingAlert, error) {
+	f.endingOn = on
+	return f.withdrawals, nil
+}
+
+func (f *fakeAlerts) ListRecipients(context.Context, model.Type) ([]model.Recipient, error) {
+	return []model.Recipient{{UserID: 1}}, nil
+}
+
+func (f *fakeAlerts) ClaimDelivery(_ context.Context, userID int, ch model.Channel, dedupKey string) (int64, bool, error) {
+	key := fmt.Sprintf("%d/%s/%s", userID, ch, dedupKey)
+	if f.claimed[key] {
+		return 0, false, nil
+	}
+	f.claimed[key] = true
+	return int64(len(f.claimed)), true, nil
+}
+
+func (f *fakeAlerts) MarkDeliverySent(context.Context, int64) error {
+	return nil
+}
+
+func (f *fakeAlerts) CreateNotification(_ context.Context, n *model.Notification) error {
+	f.inbox = append(f.inbox, *n)
+	return nil
+}
+
+func newAlertsService(t *testing.T, repo *fakeAlerts) NotificationsServiceImpl {
+	t.Helper()
+	templates, err := loadTemplates("en")
+	if err != nil {
+		t.Fatalf("loadTemplates: %v", err)
+	}
+	repo.claimed = map[string]bool{}
+	cfg := &configs.Config{}
+	cfg.Notifications.DefaultLocale = "en"
+	return NotificationsServiceImpl{
+		NotificationsRepository: repo,
+		cfg:                     cfg,
+		channels:                []Channel{NewInAppChannel(repo)},
+		templates:               templates,
+		sent:                    metrics.NewRegistry().NewCounter("notifications_sent_total", "", "type", "channel", "outcome"),
+	}
+}
+
+func TestAlertLowFeedRemindsDaily(t *testing.T) {
+	repo := &fakeAlerts{lowFeed: []model.LowFeedAlert{{
+		StockItemID:  3,
+		Feed:         "Layer pellets",
+		Remaining:    decimal.RequireFromString("12.5"),
+		ReorderLevel: decimal.RequireFromString("50"),
+		Unit:         "kg",
+	}}}
+	s := newAlertsService(t, repo)
+	day := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+
+	for _, at := range []time.Time{day, day, day.AddDate(0, 0, 1)} {
+		if err := s.alertLowFeed(context.Background(), at); err != nil {
+			t.Fatalf("alertLowFeed: %v", err)
+		}
+	}
+
+	if len(repo.inbox) != 2 {
+		t.Fatalf("inbox has %d notifications, want one per day", len(repo.inbox))
+	}
+	n := repo.inbox[0]
+	if n.Type != model.TypeLowFeed || n.Title != "Low feed: Layer pellets" {
+		t.Errorf("notification = %s %q", n.Type, n.Title)
+	}
+	if want := "Layer pellets is running low: 12.5 kg left, below the reorder level of 50 kg.\nOrder more before it runs out."; n.Body != want {
+		t.Errorf("body = %q, want %q", n.Body, want)
+	}
+}
+
+func TestAlertWithdrawalsEndingTomorrow(t *testing.T) {
+	endsOn := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	repo := &fakeAlerts{withdrawals: []model.WithdrawalEndingAlert{{
+		TreatmentID: 9,
+		AnimalID:    7,
+		Treatment:   "Oxytetracycline",
+		EndsOn:      endsOn,
+	}}}
+	s := newAlertsService(t, repo)
+	at := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if err := s.alertWithdrawalsEnding(context.Background(), at); err != nil {
+			t.Fatalf("alertWithdrawalsEnding: %v", err)
+		}
+	}
+
+	if !repo.endingOn.Equal(endsOn) {
+		t.Errorf("looked up withdrawals ending on %v, want %v", repo.endingOn, endsOn)
+	}
+	if len(repo.inbox) != 1 {
+		t.Fatalf("inbox has %d notifications, want 1", len(repo.inbox))
+	}
+	if want := "Withdrawal period ending for animal #7"; repo.inbox[0].Title != want {
+		t.Errorf("title = %q, want %q", repo.inbox[0].Title, want)
+	}
+}
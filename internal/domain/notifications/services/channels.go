@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/repository"
+)
+
+// Channel delivers notifications over one medium, such as email. A new way
+// of reaching users is added by implementing Channel and passing it to the
+// service.
+type Channel interface {
+	// Name identifies the channel in preferences. Users choose per type
+	// which channels they want.
+	Name() model.Channel
+	// Send delivers n to the recipient. It is called at most once per
+	// message and recipient unless it fails.
+	Send(ctx context.Context, to model.Recipient, n *model.Notification) error
+}
+
+// InAppChannel keeps notifications in the user's inbox.
+type InAppChannel struct {
+	repo repository.InboxRepository
+}
+
+func NewInAppChannel(repo repository.InboxRepository) *InAppChannel {
+	return &InAppChannel{repo: repo}
+}
+
+func (c *InAppChannel) Name() model.Channel {
+	return model.ChannelInApp
+}
+
+func (c *InAppChannel) Send(ctx context.Context, _ model.Recipient, n *model.Notification) error {
+	return c.repo.CreateNotification(ctx, n)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+)
+
+// smtpStub is an SMTP server accepting one message. It offers neither
+// STARTTLS nor AUTH, and records the envelope and the message data.
+type smtpStub struct {
+	listener net.Listener
+	done     chan struct{}
+
+	commands []string
+	data     []byte
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &smtpStub{listener: listener, done: make(chan struct{})}
+	go s.serve(t)
+	return s
+}
+
+func (s *smtpStub) serve(t *testing.T) {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	tp := textproto.NewConn(conn)
+	reply := func(line string) {
+		if err := tp.PrintfLine("%s", line); err != nil {
+			t.Errorf("writing reply: %v", err)
+		}
+	}
+
+	reply("220 stub ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		s.commands = append(s.commands, line)
+		switch verb := strings.ToUpper(strings.Fields(line)[0]); verb {
+		case "EHLO":
+			reply("250-stub")
+			reply("250 8BITMIME")
+		case "MAIL", "RCPT":
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			if s.data, err = io.ReadAll(tp.DotReader()); err != nil {
+				t.Errorf("reading data: %v", err)
+				return
+			}
+			reply("250 Queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Unknown command")
+		}
+	}
+}
+
+func TestEmailChannelSendsMessage(t *testing.T) {
+	stub := newSMTPStub(t)
+	host, port, err := net.SplitHostPort(stub.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %v", err)
+	}
+	channel := NewEmailChannel(configs.SMTP{
+		Host:    host,
+		Port:    port,
+		From:    "Sanika Farm <farm@example.com>",
+		Timeout: 5 * time.Second,
+	})
+
+	email := "Budi <budi@example.com>"
+	subject := "Pakan menipis: Jagung giling — gudang utama"
+	body := "Stok Jagung giling menipis: tersisa ±12,5 kg, di bawah batas pemesanan ulang 50 kg yang sudah ditentukan sebelumnya.\nSegera pesan sebelum habis."
+	err = channel.Send(context.Background(), model.Recipient{UserID: 1, Email: &email}, &model.Notification{
+		UserID: 1,
+		Type:   model.TypeLowFeed,
+		Title:  subject,
+		Body:   body,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-stub.done
+
+	// MAIL FROM may carry parameters such as BODY=8BITMIME.
+	wantCommands := []string{"MAIL FROM:<farm@example.com>", "RCPT TO:<budi@example.com>", "DATA", "QUIT"}
+	var got []string
+	for _, c := range stub.commands {
+		if !strings.HasPrefix(c, "EHLO") {
+			got = append(got, c)
+		}
+	}
+	if len(got) != len(wantCommands) {
+		t.Fatalf("commands = %q, want %q", stub.commands, wantCommands)
+	}
+	for i, want := range wantCommands {
+		if !strings.HasPrefix(got[i], want) {
+			t.Errorf("command %d = %q, want %q", i, got[i], want)
+		}
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(stub.data)))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	rawSubject := msg.Header.Get("Subject")
+	if !strings.HasPrefix(rawSubject, "=?utf-8?q?") {
+		t.Errorf("Subject = %q, want a Q-encoded word", rawSubject)
+	}
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(rawSubject); err != nil || decoded != subject {
+		t.Errorf("decoded Subject = %q (%v), want %q", decoded, err, subject)
+	}
+	if to := msg.Header.Get("To"); to != `"Budi" <budi@example.com>` {
+		t.Errorf("To = %q", to)
+	}
+	if enc := msg.Header.Get("Content-Transfer-Encoding"); enc != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %q, want quoted-printable", enc)
+	}
+
+	rawBody, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(rawBody)))
+	for scanner.Scan() {
+		if len(scanner.Text()) > 76 {
+			t.Errorf("body line of %d characters, want at most 76", len(scanner.Text()))
+		}
+	}
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(string(rawBody))))
+	if err != nil {
+		t.Fatalf("decoding body: %v", err)
+	}
+	// The stub's dot reader turns line endings into LF, and the data ends
+	// with a line break.
+	if got := strings.TrimSuffix(string(decoded), "\n"); got != body {
+		t.Errorf("body = %q, want %q", got, body)
+	}
+	if !strings.Contains(string(rawBody), "=C2=B1") {
+		t.Errorf("body %q does not quote non-ASCII characters", rawBody)
+	}
+}
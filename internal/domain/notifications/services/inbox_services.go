@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+type InboxService interface {
+	ListNotifications(ctx context.Context, params pagination.Params) ([]dto.NotificationResponse, pagination.Metadata, error)
+	MarkNotificationRead(ctx context.Context, id int64) error
+	MarkAllNotificationsRead(ctx context.Context) (dto.MarkAllReadResponse, error)
+}
+
+// ListNotifications returns one page of the current user's inbox.
+func (s NotificationsServiceImpl) ListNotifications(ctx context.Context, params pagination.Params) ([]dto.NotificationResponse, pagination.Metadata, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+	// The user filter is added here rather than allowed in the resource, so
+	// clients cannot read someone else's inbox.
+	params.Filters = append(params.Filters, pagination.Filter{
+		Field:    "userId",
+		Column:   "user_id",
+		Operator: pagination.OpEqual,
+		Value:    userID,
+	})
+
+	notifications, total, err := s.NotificationsRepository.ListNotifications(ctx, params)
+	if err != nil {
+		return nil, pagination.Metadata{}, wrapError(ctx, err, "Failed to list notifications")
+	}
+
+	res := make([]dto.NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		res = append(res, dto.NewNotificationResponse(n))
+	}
+	res, meta := pagination.Page(params, res, total, func(n dto.NotificationResponse) string {
+		return strconv.FormatInt(n.ID, 10)
+	})
+	return res, meta, nil
+}
+
+func (s NotificationsServiceImpl) MarkNotificationRead(ctx context.Context, id int64) error {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err = s.NotificationsRepository.MarkNotificationRead(ctx, userID, id); err != nil {
+		return wrapError(ctx, err, "Failed to mark notification as read")
+	}
+	return nil
+}
+
+func (s NotificationsServiceImpl) MarkAllNotificationsRead(ctx context.Context) (dto.MarkAllReadResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return dto.MarkAllReadResponse{}, err
+	}
+	updated, err := s.NotificationsRepository.MarkAllNotificationsRead(ctx, userID)
+	if err != nil {
+		return dto.MarkAllReadResponse{}, wrapError(ctx, err, "Failed to mark notifications as read")
+	}
+	return dto.MarkAllReadResponse{Updated: updated}, nil
+}
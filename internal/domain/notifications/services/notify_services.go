@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+// Notifier sends notifications. Farm alerts such as low feed are raised
+// through it.
+type Notifier interface {
+	Notify(ctx context.Context, msg model.Message) error
+}
+
+type rendered struct {
+	subject string
+	body    string
+}
+
+// Notify sends msg to every user over the channels they want for its type,
+// rendered in each user's locale. Calling it again with the same DedupKey
+// only retries the deliveries that failed, so it is safe to call from event
+// subscribers. The failures are joined.
+func (s NotificationsServiceImpl) Notify(ctx context.Context, msg model.Message) error {
+	info, ok := model.LookupType(msg.Type)
+	if !ok {
+		return fmt.Errorf("unknown notification type %q", msg.Type)
+	}
+	if msg.DedupKey == "" {
+		return errors.New("notification has no dedup key")
+	}
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return fmt.Errorf("encoding notification data: %w", err)
+	}
+
+	recipients, err := s.NotificationsRepository.ListRecipients(ctx, msg.Type)
+	if err != nil {
+		return err
+	}
+
+	defaultLocale := s.cfg.Notifications.DefaultLocale
+	byLocale := map[string]rendered{}
+	var errs []error
+	for _, to := range recipients {
+		locale := defaultLocale
+		if to.Locale != nil && *to.Locale != "" {
+			locale = *to.Locale
+		}
+		r, ok := byLocale[locale]
+		if !ok {
+			r.subject, r.body, err = s.templates.render(msg.Type, locale, defaultLocale, msg.Data)
+			if err != nil {
+				return fmt.Errorf("rendering %s: %w", msg.Type, err)
+			}
+			byLocale[locale] = r
+		}
+
+		for _, ch := range s.channels {
+			if !to.Wants(info, ch.Name()) {
+				continue
+			}
+			n := &model.Notification{
+				UserID: to.UserID,
+				Type:   msg.Type,
+				Title:  r.subject,
+				Body:   r.body,
+				Data:   data,
+			}
+			if err := s.send(ctx, ch, to, n, msg.DedupKey); err != nil {
+				errs = append(errs, fmt.Errorf("user %d over %s: %w", to.UserID, ch.Name(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send delivers n over ch unless it has already been sent for dedupKey.
+func (s NotificationsServiceImpl) send(ctx context.Context, ch Channel, to model.Recipient, n *model.Notification, dedupKey string) error {
+	id, claimed, err := s.NotificationsRepository.ClaimDelivery(ctx, to.UserID, ch.Name(), dedupKey)
+	if err != nil || !claimed {
+		return err
+	}
+
+	if err = ch.Send(ctx, to, n); err != nil {
+		s.sent.Inc(string(n.Type), string(ch.Name()), "failed")
+		logger.FromContext(ctx).Warn().Err(err).
+			Int("userId", to.UserID).Str("type", string(n.Type)).Str("channel", string(ch.Name())).
+			Msg("Failed to send notification.")
+		if markErr := s.NotificationsRepository.MarkDeliveryFailed(ctx, id, err.Error()); markErr != nil {
+			logger.FromContext(ctx).Error().Err(markErr).Msg("Failed to record notification failure.")
+		}
+		return err
+	}
+
+	s.sent.Inc(string(n.Type), string(ch.Name()), "sent")
+	return s.NotificationsRepository.MarkDeliverySent(ctx, id)
+}
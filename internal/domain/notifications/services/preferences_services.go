@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+type PreferenceService interface {
+	GetNotificationPreferences(ctx context.Context) (dto.PreferencesResponse, error)
+	UpdateNotificationPreferences(ctx context.Context, req *dto.UpdatePreferencesRequest) (dto.PreferencesResponse, error)
+}
+
+// GetNotificationPreferences returns the current user's contact settings and
+// the channels they get each notification type on.
+func (s NotificationsServiceImpl) GetNotificationPreferences(ctx context.Context) (dto.PreferencesResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return dto.PreferencesResponse{}, err
+	}
+	settings, prefs, err := s.NotificationsRepository.GetNotificationSettings(ctx, userID)
+	if err != nil {
+		return dto.PreferencesResponse{}, wrapError(ctx, err, "Failed to get notification preferences")
+	}
+	return dto.NewPreferencesResponse(settings, prefs, s.cfg.Notifications.DefaultLocale), nil
+}
+
+func (s NotificationsServiceImpl) UpdateNotificationPreferences(ctx context.Context, req *dto.UpdatePreferencesRequest) (dto.PreferencesResponse, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return dto.PreferencesResponse{}, err
+	}
+	if err = req.Validate(); err != nil {
+		return dto.PreferencesResponse{}, failure.BadRequest(err)
+	}
+
+	settings, prefs := req.ToModel(userID)
+	if err = s.NotificationsRepository.SaveNotificationSettings(ctx, settings, prefs); err != nil {
+		return dto.PreferencesResponse{}, wrapError(ctx, err, "Failed to update notification preferences")
+	}
+	return s.GetNotificationPreferences(ctx)
+}
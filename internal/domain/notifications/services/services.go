@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/events"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+)
+
+type NotificationsService interface {
+	Notifier
+	InboxService
+	PreferenceService
+}
+
+type NotificationsServiceImpl struct {
+	NotificationsRepository repository.NotificationsRepository
+	cfg                     *configs.Config
+	channels                []Channel
+	templates               templateSet
+	sent                    *metrics.Counter
+}
+
+// ProvideNotificationsService is the provider for the notifications service.
+// It notifies users in-app, and by email when SMTP is configured, and
+// subscribes to the events that raise farm alerts.
+func ProvideNotificationsService(repo repository.NotificationsRepository, cfg *configs.Config, bus *events.Bus, registry *metrics.Registry) (*NotificationsServiceImpl, error) {
+	templates, err := loadTemplates(cfg.Notifications.DefaultLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := []Channel{NewInAppChannel(repo)}
+	if cfg.Notifications.SMTP.Host != "" {
+		channels = append(channels, NewEmailChannel(cfg.Notifications.SMTP))
+	} else {
+		log.Info().Msg("SMTP is not configured, notification emails are disabled.")
+	}
+
+	s := &NotificationsServiceImpl{
+		NotificationsRepository: repo,
+		cfg:                     cfg,
+		channels:                channels,
+		templates:               templates,
+		sent: registry.NewCounter("notifications_sent_total",
+			"Total number of notifications sent by type, channel and outcome.", "type", "channel", "outcome"),
+	}
+	s.subscribe(bus)
+	return s, nil
+}
+
+// currentUser returns the ID of the authenticated user.
+func currentUser(ctx context.Context) (int, error) {
+	userID := infras.ActorFromContext(ctx)
+	if userID == nil {
+		return 0, failure.Unauthorized("authentication required")
+	}
+	return *userID, nil
+}
+
+// wrapError logs the error and turns unexpected errors into internal errors,
+// leaving failures raised by the domain untouched.
+func wrapError(ctx context.Context, err error, msg string) error {
+	if _, ok := err.(*failure.Failure); ok {
+		logger.FromContext(ctx).Warn().Err(err).Msg(msg)
+		return err
+	}
+	logger.FromContext(ctx).Error().Err(err).Msg(msg)
+	return failure.InternalError(err)
+}
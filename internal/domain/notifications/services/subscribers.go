@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	mortalityModel "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+	purchasingModel "github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/events"
+)
+
+// subscribe registers the events that notify users. Notifications raised by
+// an event are keyed by the event ID, so an event delivered twice notifies
+// once.
+func (s NotificationsServiceImpl) subscribe(bus *events.Bus) {
+	bus.Subscribe(mortalityModel.MortalityRecorded{}.EventName(), "notifications", s.onMortalityRecorded)
+	bus.Subscribe(purchasingModel.SupplierInvoiceRecorded{}.EventName(), "notifications", s.onSupplierInvoiceRecorded)
+}
+
+func (s NotificationsServiceImpl) onMortalityRecorded(ctx context.Context, msg events.Message) error {
+	var event mortalityModel.MortalityRecorded
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return fmt.Errorf("decoding %s: %w", msg.Name, err)
+	}
+	return s.Notify(ctx, model.Message{
+		Type:     model.TypeMortalityRecorded,
+		DedupKey: eventDedupKey(msg),
+		Data:     event,
+	})
+}
+
+// onSupplierInvoiceRecorded alerts about invoices that do not match what was
+// received.
+func (s NotificationsServiceImpl) onSupplierInvoiceRecorded(ctx context.Context, msg events.Message) error {
+	var event purchasingModel.SupplierInvoiceRecorded
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		return fmt.Errorf("decoding %s: %w", msg.Name, err)
+	}
+	if event.MatchStatus != purchasingModel.InvoiceMatchStatusDiscrepancy {
+		return nil
+	}
+	return s.Notify(ctx, model.Message{
+		Type:     model.TypeInvoiceDiscrepancy,
+		DedupKey: eventDedupKey(msg),
+		Data:     event,
+	})
+}
+
+func eventDedupKey(msg events.Message) string {
+	return "event:" + strconv.FormatInt(msg.ID, 10)
+}
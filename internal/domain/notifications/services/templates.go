@@ -0,0 +1,91 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+)
+
+// templateFiles holds one template per notification type and locale, named
+// <type>.<locale>.tmpl, such as farm.low_feed.en.tmpl. Each defines a
+// "subject" and a "body" template, executed with the message data.
+//
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// templateSet holds the parsed templates keyed by type and locale.
+type templateSet map[string]*template.Template
+
+// loadTemplates parses the embedded templates and checks that every
+// notification type can be rendered in defaultLocale.
+func loadTemplates(defaultLocale string) (templateSet, error) {
+	paths, err := fs.Glob(templateFiles, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	set := templateSet{}
+	for _, path := range paths {
+		name := strings.TrimSuffix(strings.TrimPrefix(path, "templates/"), ".tmpl")
+		tmpl, err := template.New(name).Option("missingkey=error").ParseFS(templateFiles, path)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range []string{"subject", "body"} {
+			if tmpl.Lookup(part) == nil {
+				return nil, fmt.Errorf("template %s does not define %q", path, part)
+			}
+		}
+		set[strings.ToLower(name)] = tmpl
+	}
+
+	for _, info := range model.Types {
+		if _, ok := set.lookup(info.Type, defaultLocale); !ok {
+			return nil, fmt.Errorf("no %s template for %s", defaultLocale, info.Type)
+		}
+	}
+	return set, nil
+}
+
+// lookup finds the template of t for locale, trying the locale as given
+// (pt-BR), then its language (pt).
+func (s templateSet) lookup(t model.Type, locale string) (*template.Template, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if tmpl, ok := s[string(t)+"."+locale]; ok {
+		return tmpl, true
+	}
+	if lang, _, found := strings.Cut(locale, "-"); found {
+		tmpl, ok := s[string(t)+"."+lang]
+		return tmpl, ok
+	}
+	return nil, false
+}
+
+// render renders the subject and body of t in locale, falling back to
+// defaultLocale when there is no template for it.
+func (s templateSet) render(t model.Type, locale, defaultLocale string, data interface{}) (subject, body string, err error) {
+	tmpl, ok := s.lookup(t, locale)
+	if !ok {
+		if tmpl, ok = s.lookup(t, defaultLocale); !ok {
+			return "", "", fmt.Errorf("no template for %s", t)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", err
+	}
+	// Subjects become email headers, so they must stay on one line.
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err = tmpl.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", err
+	}
+	return subject, strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AnimalStatusSlaughtered is the status given to animals consumed by a processing batch.
+const AnimalStatusSlaughtered = "slaughtered"
+
+// ProcessingBatch is one slaughter and processing run. It consumes animals
+// and produces packaged products, each identified by a lot code.
+type ProcessingBatch struct {
+	ID          int       `db:"id"`
+	ProcessedOn time.Time `db:"processed_on"`
+	Notes       string    `db:"notes"`
+	CreatedAt   time.Time `db:"created_at"`
+	AnimalIDs   []int     `db:"-"`
+	Products    []Product `db:"-"`
+}
+
+type Product struct {
+	ID         int             `db:"id" json:"id"`
+	BatchID    int             `db:"batch_id" json:"batchId"`
+	LotCode    string          `db:"lot_code" json:"lotCode"`
+	Name       string          `db:"name" json:"name"`
+	WeightKg   decimal.Decimal `db:"weight_kg" json:"weightKg"`
+	PackagedOn time.Time       `db:"packaged_on" json:"packagedOn"`
+	ExpiresOn  *time.Time      `db:"expires_on" json:"expiresOn,omitempty"`
+}
+
+// AssignLotCodes gives every product without a lot code one derived from the
+// batch ID and processing date, e.g. B42-260115-03.
+func (b *ProcessingBatch) AssignLotCodes() {
+	for i := range b.Products {
+		if b.Products[i].LotCode == "" {
+			b.Products[i].LotCode = fmt.Sprintf("B%d-%s-%02d", b.ID, b.ProcessedOn.Format("060102"), i+1)
+		}
+	}
+}
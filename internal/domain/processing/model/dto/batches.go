@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/processing/model"
+	"github.com/shopspring/decimal"
+)
+
+type ProductRequest struct {
+	LotCode   string          `json:"lotCode,omitempty"`
+	Name      string          `json:"name"`
+	WeightKg  decimal.Decimal `json:"weightKg"`
+	ExpiresOn *time.Time      `json:"expiresOn,omitempty"`
+}
+
+type CreateProcessingBatchRequest struct {
+	ProcessedOn time.Time        `json:"processedOn"`
+	Notes       string           `json:"notes"`
+	AnimalIDs   []int            `json:"animalIds"`
+	Products    []ProductRequest `json:"products"`
+}
+
+func (r *CreateProcessingBatchRequest) Validate() error {
+	if r.ProcessedOn.IsZero() {
+		return errors.New("processedOn is required")
+	}
+	if len(r.AnimalIDs) == 0 {
+		return errors.New("at least one animal is required")
+	}
+	seen := make(map[int]bool, len(r.AnimalIDs))
+	for _, id := range r.AnimalIDs {
+		if seen[id] {
+			return fmt.Errorf("animal %d is listed more than once", id)
+		}
+		seen[id] = true
+	}
+	if len(r.Products) == 0 {
+		return errors.New("at least one product is required")
+	}
+	lotCodes := make(map[string]bool, len(r.Products))
+	for i, p := range r.Products {
+		if p.Name == "" {
+			return fmt.Errorf("product %d: name is required", i+1)
+		}
+		if !p.WeightKg.IsPositive() {
+			return fmt.Errorf("product %d: weightKg must be greater than zero", i+1)
+		}
+		if p.LotCode != "" {
+			if lotCodes[p.LotCode] {
+				return fmt.Errorf("product %d: lot code %s is used more than once", i+1, p.LotCode)
+			}
+			lotCodes[p.LotCode] = true
+		}
+	}
+	return nil
+}
+
+func (r *CreateProcessingBatchRequest) ToModel() model.ProcessingBatch {
+	products := make([]model.Product, 0, len(r.Products))
+	for _, p := range r.Products {
+		products = append(products, model.Product{
+			LotCode:    p.LotCode,
+			Name:       p.Name,
+			WeightKg:   p.WeightKg,
+			PackagedOn: r.ProcessedOn,
+			ExpiresOn:  p.ExpiresOn,
+		})
+	}
+	return model.ProcessingBatch{
+		ProcessedOn: r.ProcessedOn,
+		Notes:       r.Notes,
+		AnimalIDs:   r.AnimalIDs,
+		Products:    products,
+	}
+}
+
+type ProcessingBatchResponse struct {
+	ID          int             `json:"id"`
+	ProcessedOn time.Time       `json:"processedOn"`
+	Notes       string          `json:"notes"`
+	AnimalIDs   []int           `json:"animalIds"`
+	Products    []model.Product `json:"products"`
+}
+
+func NewProcessingBatchResponse(b model.ProcessingBatch) ProcessingBatchResponse {
+	return ProcessingBatchResponse{
+		ID:          b.ID,
+		ProcessedOn: b.ProcessedOn,
+		Notes:       b.Notes,
+		AnimalIDs:   b.AnimalIDs,
+		Products:    b.Products,
+	}
+}
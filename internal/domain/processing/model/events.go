@@ -0,0 +1,10 @@
+package model
+
+// ProcessingBatchRecorded is published when animals are processed into
+// products.
+type ProcessingBatchRecorded struct {
+	BatchID   int   `json:"batchId"`
+	AnimalIDs []int `json:"animalIds"`
+}
+
+func (ProcessingBatchRecorded) EventName() string { return "processing.batch_recorded" }
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Trace walks a product lot back to the batch and the animals it came from.
+type Trace struct {
+	Product Product       `json:"product"`
+	Batch   TraceBatch    `json:"batch"`
+	Animals []TraceAnimal `json:"animals"`
+}
+
+type TraceBatch struct {
+	ID          int       `db:"id" json:"id"`
+	ProcessedOn time.Time `db:"processed_on" json:"processedOn"`
+	Notes       string    `db:"notes" json:"notes"`
+}
+
+type TraceAnimal struct {
+	ID         int              `db:"id" json:"id"`
+	TagNumber  string           `db:"tag_number" json:"tagNumber"`
+	Species    string           `db:"species" json:"species"`
+	BirthDate  *time.Time       `db:"birth_date" json:"birthDate,omitempty"`
+	PenID      *int             `db:"pen_id" json:"penId,omitempty"`
+	PenName    *string          `db:"pen_name" json:"penName,omitempty"`
+	Treatments []TraceTreatment `db:"-" json:"treatments"`
+	Pens       []TracePen       `db:"-" json:"pens"`
+	FeedLots   []TraceFeedLot   `db:"-" json:"feedLots"`
+}
+
+// TracePen is a stay of an animal in a pen. MovedOutOn is empty for the pen
+// the animal was in when processed.
+type TracePen struct {
+	AnimalID   int        `db:"animal_id" json:"-"`
+	PenID      int        `db:"pen_id" json:"penId"`
+	PenName    string     `db:"pen_name" json:"penName"`
+	MovedInOn  time.Time  `db:"moved_in_on" json:"movedInOn"`
+	MovedOutOn *time.Time `db:"moved_out_on" json:"movedOutOn,omitempty"`
+}
+
+type TraceTreatment struct {
+	AnimalID         int        `db:"animal_id" json:"-"`
+	Product          string     `db:"product" json:"product"`
+	Dose             string     `db:"dose" json:"dose"`
+	AdministeredOn   time.Time  `db:"administered_on" json:"administeredOn"`
+	WithdrawalEndsOn *time.Time `db:"withdrawal_ends_on" json:"withdrawalEndsOn,omitempty"`
+}
+
+// TraceFeedLot is a feed lot fed in the pens an animal stayed in, while it
+// was there.
+type TraceFeedLot struct {
+	AnimalID   int             `db:"animal_id" json:"-"`
+	LotNumber  string          `db:"lot_number" json:"lotNumber"`
+	FeedName   string          `db:"feed_name" json:"feedName"`
+	Supplier   *string         `db:"supplier" json:"supplier,omitempty"`
+	FirstFedOn time.Time       `db:"first_fed_on" json:"firstFedOn"`
+	LastFedOn  time.Time       `db:"last_fed_on" json:"lastFedOn"`
+	QuantityKg decimal.Decimal `db:"quantity_kg" json:"quantityKg"`
+}
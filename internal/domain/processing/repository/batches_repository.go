@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/processing/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var (
+	createProcessingBatch = struct {
+		LockAnimalsQuery  string
+		WithdrawalQuery   string
+		Query             string
+		AnimalQuery       string
+		UpdateAnimalQuery string
+		ProductQuery      string
+	}{
+		LockAnimalsQuery: `SELECT id, status FROM animals WHERE id IN (?) FOR UPDATE`,
+		WithdrawalQuery: `SELECT animal_id, MAX(withdrawal_ends_on) AS withdrawal_ends_on
+			FROM treatments
+			WHERE animal_id IN (?) AND withdrawal_ends_on > ?
+			GROUP BY animal_id
+			ORDER BY animal_id`,
+		Query: `INSERT INTO processing_batches (processed_on, notes)
+			VALUES ($1, $2)
+			RETURNING id, created_at`,
+		AnimalQuery:       `INSERT INTO processing_batch_animals (batch_id, animal_id) VALUES ($1, $2)`,
+		UpdateAnimalQuery: `UPDATE animals SET status = $2, updated_at = NOW() WHERE id = $1`,
+		ProductQuery: `INSERT INTO products (batch_id, lot_code, name, weight_kg, packaged_on, expires_on)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`,
+	}
+)
+
+type ProcessingBatchRepository interface {
+	CreateProcessingBatch(ctx context.Context, batch *model.ProcessingBatch) error
+}
+
+// CreateProcessingBatch records a processing batch in a single transaction.
+// Every animal must be active and out of any treatment withdrawal period; the
+// animals are marked as slaughtered and the products are given lot codes.
+func (r *ProcessingRepositoryImpl) CreateProcessingBatch(ctx context.Context, batch *model.ProcessingBatch) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		if err := r.checkAnimalsProcessable(ctx, tx, batch); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowxContext(ctx, createProcessingBatch.Query,
+			batch.ProcessedOn,
+			batch.Notes,
+		).Scan(&batch.ID, &batch.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		for _, animalID := range batch.AnimalIDs {
+			if _, err = tx.ExecContext(ctx, createProcessingBatch.AnimalQuery, batch.ID, animalID); err != nil {
+				return err
+			}
+			if _, err = tx.ExecContext(ctx, createProcessingBatch.UpdateAnimalQuery, animalID, model.AnimalStatusSlaughtered); err != nil {
+				return err
+			}
+		}
+
+		batch.AssignLotCodes()
+		for i := range batch.Products {
+			product := &batch.Products[i]
+			product.BatchID = batch.ID
+			err = tx.QueryRowxContext(ctx, createProcessingBatch.ProductQuery,
+				product.BatchID,
+				product.LotCode,
+				product.Name,
+				product.WeightKg,
+				product.PackagedOn,
+				product.ExpiresOn,
+			).Scan(&product.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		changes := []infras.AuditChange{{
+			Action:     infras.AuditCreate,
+			EntityType: "processing_batch",
+			EntityID:   batch.ID,
+			After:      batch,
+		}}
+		for _, animalID := range batch.AnimalIDs {
+			changes = append(changes, infras.AuditChange{
+				Action:     infras.AuditUpdate,
+				EntityType: "animal",
+				EntityID:   animalID,
+				Before:     map[string]string{"status": "active"},
+				After:      map[string]string{"status": model.AnimalStatusSlaughtered},
+			})
+		}
+		if err = infras.RecordAudit(ctx, tx, changes...); err != nil {
+			return err
+		}
+
+		return infras.PublishEvents(ctx, tx, model.ProcessingBatchRecorded{
+			BatchID:   batch.ID,
+			AnimalIDs: batch.AnimalIDs,
+		})
+	})
+}
+
+// checkAnimalsProcessable locks the animals and reports every one that is not
+// active or is still within a withdrawal period in a single conflict.
+func (r *ProcessingRepositoryImpl) checkAnimalsProcessable(ctx context.Context, tx *infras.Tx, batch *model.ProcessingBatch) error {
+	query, args, err := sqlx.In(createProcessingBatch.LockAnimalsQuery, batch.AnimalIDs)
+	if err != nil {
+		return err
+	}
+	var animals []struct {
+		ID     int    `db:"id"`
+		Status string `db:"status"`
+	}
+	if err = tx.SelectContext(ctx, &animals, tx.Rebind(query), args...); err != nil {
+		return err
+	}
+
+	found := make(map[int]string, len(animals))
+	for _, a := range animals {
+		found[a.ID] = a.Status
+	}
+	var conflicts []string
+	for _, id := range batch.AnimalIDs {
+		status, ok := found[id]
+		if !ok {
+			return failure.NotFound(fmt.Sprintf("animal %d", id))
+		}
+		if status != "active" {
+			conflicts = append(conflicts, fmt.Sprintf("animal %d is %s", id, status))
+		}
+	}
+
+	query, args, err = sqlx.In(createProcessingBatch.WithdrawalQuery, batch.AnimalIDs, batch.ProcessedOn)
+	if err != nil {
+		return err
+	}
+	var withdrawals []struct {
+		AnimalID         int       `db:"animal_id"`
+		WithdrawalEndsOn time.Time `db:"withdrawal_ends_on"`
+	}
+	if err = tx.SelectContext(ctx, &withdrawals, tx.Rebind(query), args...); err != nil {
+		return err
+	}
+	for _, w := range withdrawals {
+		conflicts = append(conflicts, fmt.Sprintf("animal %d is within a treatment withdrawal period until %s", w.AnimalID, w.WithdrawalEndsOn.Format("2006-01-02")))
+	}
+
+	if len(conflicts) > 0 {
+		return failure.Conflict("process", "animal", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/sanika-farm/sanika-farm-be/infras"
+
+// ProcessingRepository is the interface for repository.
+type ProcessingRepository interface {
+	ProcessingBatchRepository
+	TraceRepository
+}
+
+type ProcessingRepositoryImpl struct {
+	DB *infras.PostgresConn
+}
+
+func ProvideProcessingRepository(db *infras.PostgresConn) *ProcessingRepositoryImpl {
+	return &ProcessingRepositoryImpl{
+		DB: db,
+	}
+}
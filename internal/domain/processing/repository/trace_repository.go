@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/processing/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var (
+	getTrace = struct {
+		ProductQuery    string
+		BatchQuery      string
+		AnimalsQuery    string
+		TreatmentsQuery string
+		PensQuery       string
+		FeedLotsQuery   string
+	}{
+		ProductQuery: `SELECT id, batch_id, lot_code, name, weight_kg, packaged_on, expires_on
+			FROM products
+			WHERE lot_code = $1`,
+		BatchQuery: `SELECT id, processed_on, notes
+			FROM processing_batches
+			WHERE id = $1`,
+		AnimalsQuery: `SELECT a.id, a.tag_number, a.species, a.birth_date, a.pen_id, p.name AS pen_name
+			FROM processing_batch_animals ba
+			JOIN animals a ON a.id = ba.animal_id
+			LEFT JOIN pens p ON p.id = a.pen_id
+			WHERE ba.batch_id = $1
+			ORDER BY a.id`,
+		TreatmentsQuery: `SELECT animal_id, product, dose, administered_on, withdrawal_ends_on
+			FROM treatments
+			WHERE animal_id IN (?)
+			ORDER BY animal_id, administered_on`,
+		PensQuery: `SELECT m.animal_id, m.pen_id, p.name AS pen_name, m.moved_in_on, m.moved_out_on
+			FROM animal_pen_movements m
+			JOIN pens p ON p.id = m.pen_id
+			WHERE m.animal_id IN (?) AND m.moved_in_on <= ?
+			ORDER BY m.animal_id, m.moved_in_on`,
+		// Feed lots are matched through every pen the animal stayed in,
+		// limited to feedings during its stay there and up to the processing
+		// date. Feedings on the day of a move count for both pens.
+		FeedLotsQuery: `SELECT m.animal_id, fl.lot_number, fl.feed_name, fl.supplier,
+				MIN(f.fed_on) AS first_fed_on, MAX(f.fed_on) AS last_fed_on, SUM(f.quantity_kg) AS quantity_kg
+			FROM animal_pen_movements m
+			JOIN feedings f ON f.pen_id = m.pen_id
+				AND f.fed_on >= m.moved_in_on
+				AND (m.moved_out_on IS NULL OR f.fed_on <= m.moved_out_on)
+			JOIN feed_lots fl ON fl.id = f.feed_lot_id
+			WHERE m.animal_id IN (?) AND f.fed_on <= ?
+			GROUP BY m.animal_id, fl.id, fl.lot_number, fl.feed_name, fl.supplier
+			ORDER BY m.animal_id, first_fed_on`,
+	}
+)
+
+type TraceRepository interface {
+	GetTrace(ctx context.Context, lotCode string) (model.Trace, error)
+}
+
+// GetTrace resolves a product lot back to its batch, source animals, their
+// treatments, the pens they passed through and the feed lots they were fed.
+func (r *ProcessingRepositoryImpl) GetTrace(ctx context.Context, lotCode string) (model.Trace, error) {
+	var trace model.Trace
+	err := r.DB.Reader(ctx).GetContext(ctx, &trace.Product, getTrace.ProductQuery, lotCode)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return trace, failure.NotFound("product lot")
+		}
+		return trace, err
+	}
+
+	if err = r.DB.Reader(ctx).GetContext(ctx, &trace.Batch, getTrace.BatchQuery, trace.Product.BatchID); err != nil {
+		return trace, err
+	}
+
+	trace.Animals = []model.TraceAnimal{}
+	if err = r.DB.Reader(ctx).SelectContext(ctx, &trace.Animals, getTrace.AnimalsQuery, trace.Batch.ID); err != nil {
+		return trace, err
+	}
+	if len(trace.Animals) == 0 {
+		return trace, nil
+	}
+
+	ids := make([]int, 0, len(trace.Animals))
+	animals := make(map[int]*model.TraceAnimal, len(trace.Animals))
+	for i := range trace.Animals {
+		animal := &trace.Animals[i]
+		animal.Treatments = []model.TraceTreatment{}
+		animal.Pens = []model.TracePen{}
+		animal.FeedLots = []model.TraceFeedLot{}
+		ids = append(ids, animal.ID)
+		animals[animal.ID] = animal
+	}
+
+	var treatments []model.TraceTreatment
+	if err = r.selectIn(ctx, &treatments, getTrace.TreatmentsQuery, ids); err != nil {
+		return trace, err
+	}
+	for _, t := range treatments {
+		animals[t.AnimalID].Treatments = append(animals[t.AnimalID].Treatments, t)
+	}
+
+	var pens []model.TracePen
+	if err = r.selectIn(ctx, &pens, getTrace.PensQuery, ids, trace.Batch.ProcessedOn); err != nil {
+		return trace, err
+	}
+	for _, p := range pens {
+		animals[p.AnimalID].Pens = append(animals[p.AnimalID].Pens, p)
+	}
+
+	var feedLots []model.TraceFeedLot
+	if err = r.selectIn(ctx, &feedLots, getTrace.FeedLotsQuery, ids, trace.Batch.ProcessedOn); err != nil {
+		return trace, err
+	}
+	for _, f := range feedLots {
+		animals[f.AnimalID].FeedLots = append(animals[f.AnimalID].FeedLots, f)
+	}
+
+	return trace, nil
+}
+
+func (r *ProcessingRepositoryImpl) selectIn(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	return r.DB.Reader(ctx).SelectContext(ctx, dest, r.DB.Reader(ctx).Rebind(query), args...)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/processing/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type ProcessingBatchService interface {
+	CreateProcessingBatch(ctx context.Context, req *dto.CreateProcessingBatchRequest) (dto.ProcessingBatchResponse, error)
+}
+
+func (s ProcessingServiceImpl) CreateProcessingBatch(ctx context.Context, req *dto.CreateProcessingBatchRequest) (dto.ProcessingBatchResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.ProcessingBatchResponse{}, failure.BadRequest(err)
+	}
+
+	batch := req.ToModel()
+	err := s.ProcessingRepository.CreateProcessingBatch(ctx, &batch)
+	if err != nil {
+		if failure.GetCode(err) >= 500 {
+			logger.FromContext(ctx).Error().Err(err).Msg("Failed to create processing batch")
+			return dto.ProcessingBatchResponse{}, failure.InternalError(err)
+		}
+		logger.FromContext(ctx).Warn().Err(err).Msg("Failed to create processing batch")
+		return dto.ProcessingBatchResponse{}, err
+	}
+	return dto.NewProcessingBatchResponse(batch), nil
+}
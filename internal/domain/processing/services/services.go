@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/processing/repository"
+)
+
+type ProcessingService interface {
+	ProcessingBatchService
+	TraceService
+}
+
+type ProcessingServiceImpl struct {
+	ProcessingRepository repository.ProcessingRepository
+	cfg                  *configs.Config
+}
+
+func ProvideProcessingService(repo repository.ProcessingRepository, cfg *configs.Config) *ProcessingServiceImpl {
+	return &ProcessingServiceImpl{
+		ProcessingRepository: repo,
+		cfg:                  cfg,
+	}
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/processing/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type TraceService interface {
+	GetTrace(ctx context.Context, lotCode string) (model.Trace, error)
+}
+
+func (s ProcessingServiceImpl) GetTrace(ctx context.Context, lotCode string) (model.Trace, error) {
+	trace, err := s.ProcessingRepository.GetTrace(ctx, lotCode)
+	if err != nil {
+		if failure.GetCode(err) >= 500 {
+			logger.FromContext(ctx).Error().Err(err).Str("lotCode", lotCode).Msg("Failed to trace product lot")
+			return model.Trace{}, failure.InternalError(err)
+		}
+		return model.Trace{}, err
+	}
+	return trace, nil
+}
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/shopspring/decimal"
+)
+
+type SupplierInvoiceLineRequest struct {
+	PurchaseOrderLineID int             `json:"purchaseOrderLineId"`
+	Quantity            decimal.Decimal `json:"quantity"`
+	UnitPrice           decimal.Decimal `json:"unitPrice"`
+}
+
+type CreateSupplierInvoiceRequest struct {
+	InvoiceNumber string                       `json:"invoiceNumber"`
+	InvoiceDate   time.Time                    `json:"invoiceDate"`
+	Amount        decimal.Decimal              `json:"amount"`
+	Lines         []SupplierInvoiceLineRequest `json:"lines"`
+}
+
+func (r *CreateSupplierInvoiceRequest) Validate() error {
+	if strings.TrimSpace(r.InvoiceNumber) == "" {
+		return errors.New("invoiceNumber is required")
+	}
+	if r.InvoiceDate.IsZero() {
+		return errors.New("invoiceDate is required")
+	}
+	if len(r.Lines) == 0 {
+		return errors.New("at least one line is required")
+	}
+	for i, line := range r.Lines {
+		if line.PurchaseOrderLineID <= 0 {
+			return fmt.Errorf("line %d: purchaseOrderLineId is required", i+1)
+		}
+		if !line.Quantity.IsPositive() {
+			return fmt.Errorf("line %d: quantity must be greater than zero", i+1)
+		}
+	}
+	return nil
+}
+
+func (r *CreateSupplierInvoiceRequest) ToModel(purchaseOrderID int) model.SupplierInvoice {
+	lines := make([]model.SupplierInvoiceLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, model.SupplierInvoiceLine{
+			PurchaseOrderLineID: line.PurchaseOrderLineID,
+			Quantity:            line.Quantity,
+			UnitPrice:           line.UnitPrice,
+		})
+	}
+	return model.SupplierInvoice{
+		PurchaseOrderID: purchaseOrderID,
+		InvoiceNumber:   strings.TrimSpace(r.InvoiceNumber),
+		InvoiceDate:     r.InvoiceDate,
+		Amount:          r.Amount,
+		Lines:           lines,
+	}
+}
+
+type SupplierInvoiceResponse struct {
+	ID              int                      `json:"id"`
+	SupplierID      int                      `json:"supplierId"`
+	PurchaseOrderID int                      `json:"purchaseOrderId"`
+	InvoiceNumber   string                   `json:"invoiceNumber"`
+	InvoiceDate     time.Time                `json:"invoiceDate"`
+	Amount          decimal.Decimal          `json:"amount"`
+	MatchStatus     model.InvoiceMatchStatus `json:"matchStatus"`
+	MatchNotes      string                   `json:"matchNotes,omitempty"`
+}
+
+func NewSupplierInvoiceResponse(inv model.SupplierInvoice) SupplierInvoiceResponse {
+	return SupplierInvoiceResponse{
+		ID:              inv.ID,
+		SupplierID:      inv.SupplierID,
+		PurchaseOrderID: inv.PurchaseOrderID,
+		InvoiceNumber:   inv.InvoiceNumber,
+		InvoiceDate:     inv.InvoiceDate,
+		Amount:          inv.Amount,
+		MatchStatus:     inv.MatchStatus,
+		MatchNotes:      inv.MatchNotes,
+	}
+}
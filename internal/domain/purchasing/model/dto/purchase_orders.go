@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderLineRequest struct {
+	StockItemID int             `json:"stockItemId"`
+	Description string          `json:"description"`
+	Quantity    decimal.Decimal `json:"quantity"`
+	UnitPrice   decimal.Decimal `json:"unitPrice"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	SupplierID int                        `json:"supplierId"`
+	OrderedOn  time.Time                  `json:"orderedOn"`
+	ExpectedOn *time.Time                 `json:"expectedOn,omitempty"`
+	Notes      string                     `json:"notes"`
+	Lines      []PurchaseOrderLineRequest `json:"lines"`
+}
+
+func (r *CreatePurchaseOrderRequest) Validate() error {
+	if r.SupplierID <= 0 {
+		return errors.New("supplierId is required")
+	}
+	if r.OrderedOn.IsZero() {
+		return errors.New("orderedOn is required")
+	}
+	if len(r.Lines) == 0 {
+		return errors.New("at least one line is required")
+	}
+	for i, line := range r.Lines {
+		if line.StockItemID <= 0 {
+			return fmt.Errorf("line %d: stockItemId is required", i+1)
+		}
+		if !line.Quantity.IsPositive() {
+			return fmt.Errorf("line %d: quantity must be greater than zero", i+1)
+		}
+		if line.UnitPrice.IsNegative() {
+			return fmt.Errorf("line %d: unitPrice must not be negative", i+1)
+		}
+	}
+	return nil
+}
+
+// ToModel builds a draft purchase order from the request.
+func (r *CreatePurchaseOrderRequest) ToModel() model.PurchaseOrder {
+	lines := make([]model.PurchaseOrderLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, model.PurchaseOrderLine{
+			StockItemID:      line.StockItemID,
+			Description:      line.Description,
+			QuantityOrdered:  line.Quantity,
+			QuantityReceived: decimal.Zero,
+			UnitPrice:        line.UnitPrice,
+		})
+	}
+	return model.PurchaseOrder{
+		SupplierID: r.SupplierID,
+		Status:     model.PurchaseOrderStatusDraft,
+		OrderedOn:  r.OrderedOn,
+		ExpectedOn: r.ExpectedOn,
+		Notes:      r.Notes,
+		Lines:      lines,
+	}
+}
+
+type PurchaseOrderLineResponse struct {
+	ID               int             `json:"id"`
+	StockItemID      int             `json:"stockItemId"`
+	Description      string          `json:"description"`
+	QuantityOrdered  decimal.Decimal `json:"quantityOrdered"`
+	QuantityReceived decimal.Decimal `json:"quantityReceived"`
+	UnitPrice        decimal.Decimal `json:"unitPrice"`
+}
+
+type PurchaseOrderResponse struct {
+	ID         int                         `json:"id"`
+	SupplierID int                         `json:"supplierId"`
+	Status     model.PurchaseOrderStatus   `json:"status"`
+	OrderedOn  time.Time                   `json:"orderedOn"`
+	ExpectedOn *time.Time                  `json:"expectedOn,omitempty"`
+	Notes      string                      `json:"notes"`
+	Lines      []PurchaseOrderLineResponse `json:"lines"`
+}
+
+func NewPurchaseOrderResponse(po model.PurchaseOrder) PurchaseOrderResponse {
+	lines := make([]PurchaseOrderLineResponse, 0, len(po.Lines))
+	for _, line := range po.Lines {
+		lines = append(lines, PurchaseOrderLineResponse{
+			ID:               line.ID,
+			StockItemID:      line.StockItemID,
+			Description:      line.Description,
+			QuantityOrdered:  line.QuantityOrdered,
+			QuantityReceived: line.QuantityReceived,
+			UnitPrice:        line.UnitPrice,
+		})
+	}
+	return PurchaseOrderResponse{
+		ID:         po.ID,
+		SupplierID: po.SupplierID,
+		Status:     po.Status,
+		OrderedOn:  po.OrderedOn,
+		ExpectedOn: po.ExpectedOn,
+		Notes:      po.Notes,
+		Lines:      lines,
+	}
+}
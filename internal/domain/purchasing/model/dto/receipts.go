@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/shopspring/decimal"
+)
+
+type GoodsReceiptLineRequest struct {
+	PurchaseOrderLineID int             `json:"purchaseOrderLineId"`
+	Quantity            decimal.Decimal `json:"quantity"`
+}
+
+type ReceiveGoodsRequest struct {
+	ReceivedOn time.Time                 `json:"receivedOn"`
+	Notes      string                    `json:"notes"`
+	Lines      []GoodsReceiptLineRequest `json:"lines"`
+}
+
+func (r *ReceiveGoodsRequest) Validate() error {
+	if r.ReceivedOn.IsZero() {
+		return errors.New("receivedOn is required")
+	}
+	if len(r.Lines) == 0 {
+		return errors.New("at least one line is required")
+	}
+	for i, line := range r.Lines {
+		if line.PurchaseOrderLineID <= 0 {
+			return fmt.Errorf("line %d: purchaseOrderLineId is required", i+1)
+		}
+		if !line.Quantity.IsPositive() {
+			return fmt.Errorf("line %d: quantity must be greater than zero", i+1)
+		}
+	}
+	return nil
+}
+
+func (r *ReceiveGoodsRequest) ToModel(purchaseOrderID int) model.GoodsReceipt {
+	lines := make([]model.GoodsReceiptLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, model.GoodsReceiptLine{
+			PurchaseOrderLineID: line.PurchaseOrderLineID,
+			Quantity:            line.Quantity,
+		})
+	}
+	return model.GoodsReceipt{
+		PurchaseOrderID: purchaseOrderID,
+		ReceivedOn:      r.ReceivedOn,
+		Notes:           r.Notes,
+		Lines:           lines,
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+)
+
+type CreateSupplierRequest struct {
+	Name        string `json:"name"`
+	ContactName string `json:"contactName"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email"`
+	Address     string `json:"address"`
+}
+
+func (r *CreateSupplierRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func (r *CreateSupplierRequest) ToModel() model.Supplier {
+	return model.Supplier{
+		Name:        strings.TrimSpace(r.Name),
+		ContactName: r.ContactName,
+		Phone:       r.Phone,
+		Email:       r.Email,
+		Address:     r.Address,
+	}
+}
@@ -0,0 +1,24 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// GoodsReceived is published when goods are received against a purchase order.
+type GoodsReceived struct {
+	GoodsReceiptID  int                 `json:"goodsReceiptId"`
+	PurchaseOrderID int                 `json:"purchaseOrderId"`
+	Status          PurchaseOrderStatus `json:"status"`
+}
+
+func (GoodsReceived) EventName() string { return "purchasing.goods_received" }
+
+// SupplierInvoiceRecorded is published when a supplier invoice has been
+// matched and stored.
+type SupplierInvoiceRecorded struct {
+	SupplierInvoiceID int                `json:"supplierInvoiceId"`
+	SupplierID        int                `json:"supplierId"`
+	PurchaseOrderID   int                `json:"purchaseOrderId"`
+	Amount            decimal.Decimal    `json:"amount"`
+	MatchStatus       InvoiceMatchStatus `json:"matchStatus"`
+}
+
+func (SupplierInvoiceRecorded) EventName() string { return "purchasing.supplier_invoice_recorded" }
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// InvoiceMatchStatus is the outcome of matching a supplier invoice against
+// the purchase order and what has been received so far.
+type InvoiceMatchStatus string
+
+const (
+	InvoiceMatchStatusMatched     InvoiceMatchStatus = "matched"
+	InvoiceMatchStatusDiscrepancy InvoiceMatchStatus = "discrepancy"
+)
+
+type SupplierInvoice struct {
+	ID              int                   `db:"id"`
+	SupplierID      int                   `db:"supplier_id"`
+	PurchaseOrderID int                   `db:"purchase_order_id"`
+	InvoiceNumber   string                `db:"invoice_number"`
+	InvoiceDate     time.Time             `db:"invoice_date"`
+	Amount          decimal.Decimal       `db:"amount"`
+	MatchStatus     InvoiceMatchStatus    `db:"match_status"`
+	MatchNotes      string                `db:"match_notes"`
+	CreatedAt       time.Time             `db:"created_at"`
+	Lines           []SupplierInvoiceLine `db:"-"`
+}
+
+type SupplierInvoiceLine struct {
+	ID                  int             `db:"id"`
+	SupplierInvoiceID   int             `db:"supplier_invoice_id"`
+	PurchaseOrderLineID int             `db:"purchase_order_line_id"`
+	Quantity            decimal.Decimal `db:"quantity"`
+	UnitPrice           decimal.Decimal `db:"unit_price"`
+}
+
+// Match compares the invoice with the purchase order lines. Each invoiced
+// quantity, together with what was invoiced before, must not exceed the
+// quantity received, unit prices must equal the ordered prices, and the
+// invoice amount must equal the sum of its lines.
+func (inv *SupplierInvoice) Match(po PurchaseOrder, previouslyInvoiced map[int]decimal.Decimal) {
+	lines := make(map[int]PurchaseOrderLine, len(po.Lines))
+	for _, line := range po.Lines {
+		lines[line.ID] = line
+	}
+
+	var notes []string
+	total := decimal.Zero
+	for _, il := range inv.Lines {
+		total = total.Add(il.Quantity.Mul(il.UnitPrice))
+
+		line, ok := lines[il.PurchaseOrderLineID]
+		if !ok {
+			notes = append(notes, fmt.Sprintf("line %d is not on purchase order %d", il.PurchaseOrderLineID, po.ID))
+			continue
+		}
+		invoiced := previouslyInvoiced[line.ID].Add(il.Quantity)
+		if invoiced.GreaterThan(line.QuantityReceived) {
+			notes = append(notes, fmt.Sprintf("line %d: invoiced %s exceeds received %s", line.ID, invoiced, line.QuantityReceived))
+		}
+		if !il.UnitPrice.Equal(line.UnitPrice) {
+			notes = append(notes, fmt.Sprintf("line %d: invoiced price %s differs from ordered price %s", line.ID, il.UnitPrice, line.UnitPrice))
+		}
+	}
+	if !total.Equal(inv.Amount) {
+		notes = append(notes, fmt.Sprintf("invoice amount %s differs from line total %s", inv.Amount, total))
+	}
+
+	inv.MatchStatus = InvoiceMatchStatusMatched
+	inv.MatchNotes = ""
+	if len(notes) > 0 {
+		inv.MatchStatus = InvoiceMatchStatusDiscrepancy
+		inv.MatchNotes = strings.Join(notes, "; ")
+	}
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/shopspring/decimal"
+)
+
+// PurchaseOrderStatus is the lifecycle state of a purchase order.
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "closed"
+)
+
+// purchaseOrderTransitions lists the states each state may move to.
+var purchaseOrderTransitions = map[PurchaseOrderStatus][]PurchaseOrderStatus{
+	PurchaseOrderStatusDraft:             {PurchaseOrderStatusSent},
+	PurchaseOrderStatusSent:              {PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived},
+	PurchaseOrderStatusPartiallyReceived: {PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived},
+	PurchaseOrderStatusReceived:          {PurchaseOrderStatusClosed},
+}
+
+// CanTransitionTo reports whether a purchase order may move from s to next.
+func (s PurchaseOrderStatus) CanTransitionTo(next PurchaseOrderStatus) bool {
+	for _, allowed := range purchaseOrderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type PurchaseOrder struct {
+	ID         int                 `db:"id"`
+	SupplierID int                 `db:"supplier_id"`
+	Status     PurchaseOrderStatus `db:"status"`
+	OrderedOn  time.Time           `db:"ordered_on"`
+	ExpectedOn *time.Time          `db:"expected_on"`
+	Notes      string              `db:"notes"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at"`
+	Lines      []PurchaseOrderLine `db:"-"`
+}
+
+// PurchaseOrderLine is a single stock item ordered on a purchase order.
+type PurchaseOrderLine struct {
+	ID               int             `db:"id"`
+	PurchaseOrderID  int             `db:"purchase_order_id"`
+	StockItemID      int             `db:"stock_item_id"`
+	Description      string          `db:"description"`
+	QuantityOrdered  decimal.Decimal `db:"quantity_ordered"`
+	QuantityReceived decimal.Decimal `db:"quantity_received"`
+	UnitPrice        decimal.Decimal `db:"unit_price"`
+}
+
+// Outstanding returns the quantity still to be received on the line.
+func (l PurchaseOrderLine) Outstanding() decimal.Decimal {
+	return l.QuantityOrdered.Sub(l.QuantityReceived)
+}
+
+// TransitionTo moves the purchase order to next, or fails with a conflict if
+// the lifecycle does not allow it.
+func (po *PurchaseOrder) TransitionTo(next PurchaseOrderStatus) error {
+	if !po.Status.CanTransitionTo(next) {
+		return failure.Conflict("transition", "purchase order",
+			fmt.Sprintf("cannot move from %s to %s", po.Status, next))
+	}
+	po.Status = next
+	return nil
+}
+
+// ApplyReceipt adds the received quantities to the order lines and moves the
+// order to partially_received or received accordingly.
+func (po *PurchaseOrder) ApplyReceipt(receipt GoodsReceipt) error {
+	lines := make(map[int]*PurchaseOrderLine, len(po.Lines))
+	for i := range po.Lines {
+		lines[po.Lines[i].ID] = &po.Lines[i]
+	}
+
+	for _, rl := range receipt.Lines {
+		line, ok := lines[rl.PurchaseOrderLineID]
+		if !ok {
+			return failure.BadRequestFromString(fmt.Sprintf("line %d does not belong to purchase order %d", rl.PurchaseOrderLineID, po.ID))
+		}
+		if rl.Quantity.GreaterThan(line.Outstanding()) {
+			return failure.BadRequestFromString(fmt.Sprintf("line %d: received %s exceeds outstanding %s", line.ID, rl.Quantity, line.Outstanding()))
+		}
+		line.QuantityReceived = line.QuantityReceived.Add(rl.Quantity)
+	}
+
+	next := PurchaseOrderStatusReceived
+	for _, line := range po.Lines {
+		if line.Outstanding().IsPositive() {
+			next = PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+	return po.TransitionTo(next)
+}
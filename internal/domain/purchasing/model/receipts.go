@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// GoodsReceipt records a delivery against a purchase order. A purchase order
+// may be received across several receipts.
+type GoodsReceipt struct {
+	ID              int                `db:"id"`
+	PurchaseOrderID int                `db:"purchase_order_id"`
+	ReceivedOn      time.Time          `db:"received_on"`
+	Notes           string             `db:"notes"`
+	CreatedAt       time.Time          `db:"created_at"`
+	Lines           []GoodsReceiptLine `db:"-"`
+}
+
+type GoodsReceiptLine struct {
+	ID                  int             `db:"id"`
+	GoodsReceiptID      int             `db:"goods_receipt_id"`
+	PurchaseOrderLineID int             `db:"purchase_order_line_id"`
+	Quantity            decimal.Decimal `db:"quantity"`
+}
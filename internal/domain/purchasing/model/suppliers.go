@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type Supplier struct {
+	ID          int       `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	ContactName string    `db:"contact_name" json:"contactName"`
+	Phone       string    `db:"phone" json:"phone"`
+	Email       string    `db:"email" json:"email"`
+	Address     string    `db:"address" json:"address"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	createSupplierInvoice = struct {
+		Query         string
+		LineQuery     string
+		InvoicedQuery string
+	}{
+		Query: `INSERT INTO supplier_invoices (supplier_id, purchase_order_id, invoice_number, invoice_date, amount, match_status, match_notes)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at`,
+		LineQuery: `INSERT INTO supplier_invoice_lines (supplier_invoice_id, purchase_order_line_id, quantity, unit_price)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`,
+		InvoicedQuery: `SELECT l.purchase_order_line_id, SUM(l.quantity) AS quantity
+			FROM supplier_invoice_lines l
+			JOIN supplier_invoices i ON i.id = l.supplier_invoice_id
+			WHERE i.purchase_order_id = $1
+			GROUP BY l.purchase_order_line_id`,
+	}
+)
+
+type SupplierInvoiceRepository interface {
+	CreateSupplierInvoice(ctx context.Context, invoice *model.SupplierInvoice) error
+}
+
+// CreateSupplierInvoice matches an invoice against the received quantities of
+// its purchase order and stores it with the match outcome.
+func (r *PurchasingRepositoryImpl) CreateSupplierInvoice(ctx context.Context, invoice *model.SupplierInvoice) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		po, err := r.getPurchaseOrderForUpdate(ctx, tx, invoice.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+		if po.Status == model.PurchaseOrderStatusDraft {
+			return failure.Conflict("invoice", "purchase order", "purchase order has not been sent")
+		}
+
+		var invoicedRows []struct {
+			PurchaseOrderLineID int             `db:"purchase_order_line_id"`
+			Quantity            decimal.Decimal `db:"quantity"`
+		}
+		err = tx.SelectContext(ctx, &invoicedRows, createSupplierInvoice.InvoicedQuery, po.ID)
+		if err != nil {
+			return err
+		}
+		invoiced := make(map[int]decimal.Decimal, len(invoicedRows))
+		for _, row := range invoicedRows {
+			invoiced[row.PurchaseOrderLineID] = row.Quantity
+		}
+
+		invoice.SupplierID = po.SupplierID
+		invoice.Match(po, invoiced)
+
+		err = tx.QueryRowxContext(ctx, createSupplierInvoice.Query,
+			invoice.SupplierID,
+			invoice.PurchaseOrderID,
+			invoice.InvoiceNumber,
+			invoice.InvoiceDate,
+			invoice.Amount,
+			invoice.MatchStatus,
+			invoice.MatchNotes,
+		).Scan(&invoice.ID, &invoice.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		for i := range invoice.Lines {
+			line := &invoice.Lines[i]
+			line.SupplierInvoiceID = invoice.ID
+			err = tx.QueryRowxContext(ctx, createSupplierInvoice.LineQuery,
+				line.SupplierInvoiceID,
+				line.PurchaseOrderLineID,
+				line.Quantity,
+				line.UnitPrice,
+			).Scan(&line.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditCreate,
+			EntityType: "supplier_invoice",
+			EntityID:   invoice.ID,
+			After:      invoice,
+		})
+		if err != nil {
+			return err
+		}
+
+		return infras.PublishEvents(ctx, tx, model.SupplierInvoiceRecorded{
+			SupplierInvoiceID: invoice.ID,
+			SupplierID:        invoice.SupplierID,
+			PurchaseOrderID:   invoice.PurchaseOrderID,
+			Amount:            invoice.Amount,
+			MatchStatus:       invoice.MatchStatus,
+		})
+	})
+}
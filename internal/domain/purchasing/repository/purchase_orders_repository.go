@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var (
+	createPurchaseOrder = struct {
+		Query     string
+		LineQuery string
+	}{
+		Query: `INSERT INTO purchase_orders (supplier_id, status, ordered_on, expected_on, notes)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at, updated_at`,
+		LineQuery: `INSERT INTO purchase_order_lines (purchase_order_id, stock_item_id, description, quantity_ordered, quantity_received, unit_price)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`,
+	}
+
+	getPurchaseOrder = struct {
+		Query          string
+		ForUpdateQuery string
+		LinesQuery     string
+	}{
+		Query: `SELECT id, supplier_id, status, ordered_on, expected_on, notes, created_at, updated_at
+			FROM purchase_orders
+			WHERE id = $1`,
+		ForUpdateQuery: `SELECT id, supplier_id, status, ordered_on, expected_on, notes, created_at, updated_at
+			FROM purchase_orders
+			WHERE id = $1
+			FOR UPDATE`,
+		LinesQuery: `SELECT id, purchase_order_id, stock_item_id, description, quantity_ordered, quantity_received, unit_price
+			FROM purchase_order_lines
+			WHERE purchase_order_id = $1
+			ORDER BY id`,
+	}
+
+	updatePurchaseOrderStatus = struct {
+		Query string
+	}{
+		Query: `UPDATE purchase_orders
+			SET status = $2, updated_at = NOW()
+			WHERE id = $1
+			RETURNING updated_at`,
+	}
+)
+
+type PurchaseOrderRepository interface {
+	CreatePurchaseOrder(ctx context.Context, po *model.PurchaseOrder) error
+	GetPurchaseOrder(ctx context.Context, id int) (model.PurchaseOrder, error)
+	TransitionPurchaseOrder(ctx context.Context, id int, next model.PurchaseOrderStatus) (model.PurchaseOrder, error)
+}
+
+// CreatePurchaseOrder stores a purchase order and its lines in a single transaction.
+func (r *PurchasingRepositoryImpl) CreatePurchaseOrder(ctx context.Context, po *model.PurchaseOrder) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		err := tx.QueryRowxContext(ctx, createPurchaseOrder.Query,
+			po.SupplierID,
+			po.Status,
+			po.OrderedOn,
+			po.ExpectedOn,
+			po.Notes,
+		).Scan(&po.ID, &po.CreatedAt, &po.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		for i := range po.Lines {
+			line := &po.Lines[i]
+			line.PurchaseOrderID = po.ID
+			err = tx.QueryRowxContext(ctx, createPurchaseOrder.LineQuery,
+				line.PurchaseOrderID,
+				line.StockItemID,
+				line.Description,
+				line.QuantityOrdered,
+				line.QuantityReceived,
+				line.UnitPrice,
+			).Scan(&line.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditCreate,
+			EntityType: "purchase_order",
+			EntityID:   po.ID,
+			After:      po,
+		})
+	})
+}
+
+func (r *PurchasingRepositoryImpl) GetPurchaseOrder(ctx context.Context, id int) (model.PurchaseOrder, error) {
+	var po model.PurchaseOrder
+	err := r.DB.Reader(ctx).GetContext(ctx, &po, getPurchaseOrder.Query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return po, failure.NotFound("purchase order")
+		}
+		return po, err
+	}
+
+	err = r.DB.Reader(ctx).SelectContext(ctx, &po.Lines, getPurchaseOrder.LinesQuery, id)
+	return po, err
+}
+
+// TransitionPurchaseOrder moves a purchase order to the next status after
+// checking the transition against the locked row.
+func (r *PurchasingRepositoryImpl) TransitionPurchaseOrder(ctx context.Context, id int, next model.PurchaseOrderStatus) (model.PurchaseOrder, error) {
+	var po model.PurchaseOrder
+	err := r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		var err error
+		po, err = r.getPurchaseOrderForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		before := po
+
+		if err = po.TransitionTo(next); err != nil {
+			return err
+		}
+
+		if err = r.updatePurchaseOrderStatus(ctx, tx, &po); err != nil {
+			return err
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditUpdate,
+			EntityType: "purchase_order",
+			EntityID:   po.ID,
+			Before:     before,
+			After:      po,
+		})
+	})
+	return po, err
+}
+
+// getPurchaseOrderForUpdate loads a purchase order with its lines and locks
+// the order row until the transaction ends.
+func (r *PurchasingRepositoryImpl) getPurchaseOrderForUpdate(ctx context.Context, tx *infras.Tx, id int) (model.PurchaseOrder, error) {
+	var po model.PurchaseOrder
+	err := tx.GetContext(ctx, &po, getPurchaseOrder.ForUpdateQuery, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return po, failure.NotFound("purchase order")
+		}
+		return po, err
+	}
+
+	err = tx.SelectContext(ctx, &po.Lines, getPurchaseOrder.LinesQuery, id)
+	return po, err
+}
+
+func (r *PurchasingRepositoryImpl) updatePurchaseOrderStatus(ctx context.Context, tx *infras.Tx, po *model.PurchaseOrder) error {
+	return tx.QueryRowxContext(ctx, updatePurchaseOrderStatus.Query, po.ID, po.Status).Scan(&po.UpdatedAt)
+}
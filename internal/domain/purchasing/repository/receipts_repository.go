@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var (
+	createGoodsReceipt = struct {
+		Query         string
+		LineQuery     string
+		UpdateLine    string
+		IncreaseStock string
+	}{
+		Query: `INSERT INTO goods_receipts (purchase_order_id, received_on, notes)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at`,
+		LineQuery: `INSERT INTO goods_receipt_lines (goods_receipt_id, purchase_order_line_id, quantity)
+			VALUES ($1, $2, $3)
+			RETURNING id`,
+		UpdateLine: `UPDATE purchase_order_lines
+			SET quantity_received = $2
+			WHERE id = $1`,
+		IncreaseStock: `UPDATE stock_items
+			SET quantity_on_hand = quantity_on_hand + $2, updated_at = NOW()
+			WHERE id = $1`,
+	}
+)
+
+type GoodsReceiptRepository interface {
+	ReceiveGoods(ctx context.Context, receipt *model.GoodsReceipt) (model.PurchaseOrder, error)
+}
+
+// ReceiveGoods records a goods receipt against a purchase order. In one
+// transaction it updates the received quantities, increases stock on hand,
+// and moves the order to partially_received or received.
+func (r *PurchasingRepositoryImpl) ReceiveGoods(ctx context.Context, receipt *model.GoodsReceipt) (model.PurchaseOrder, error) {
+	var po model.PurchaseOrder
+	err := r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		var err error
+		po, err = r.getPurchaseOrderForUpdate(ctx, tx, receipt.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+
+		before := po
+		before.Lines = append([]model.PurchaseOrderLine(nil), po.Lines...)
+
+		if err = po.ApplyReceipt(*receipt); err != nil {
+			return err
+		}
+
+		err = tx.QueryRowxContext(ctx, createGoodsReceipt.Query,
+			receipt.PurchaseOrderID,
+			receipt.ReceivedOn,
+			receipt.Notes,
+		).Scan(&receipt.ID, &receipt.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		stockItems := make(map[int]int, len(po.Lines))
+		for _, line := range po.Lines {
+			stockItems[line.ID] = line.StockItemID
+		}
+
+		for i := range receipt.Lines {
+			line := &receipt.Lines[i]
+			line.GoodsReceiptID = receipt.ID
+			err = tx.QueryRowxContext(ctx, createGoodsReceipt.LineQuery,
+				line.GoodsReceiptID,
+				line.PurchaseOrderLineID,
+				line.Quantity,
+			).Scan(&line.ID)
+			if err != nil {
+				return err
+			}
+
+			res, err := tx.ExecContext(ctx, createGoodsReceipt.IncreaseStock, stockItems[line.PurchaseOrderLineID], line.Quantity)
+			if err != nil {
+				return err
+			}
+			if n, err := res.RowsAffected(); err == nil && n == 0 {
+				return failure.NotFound("stock item")
+			}
+		}
+
+		for _, line := range po.Lines {
+			_, err = tx.ExecContext(ctx, createGoodsReceipt.UpdateLine, line.ID, line.QuantityReceived)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = r.updatePurchaseOrderStatus(ctx, tx, &po); err != nil {
+			return err
+		}
+
+		err = infras.RecordAudit(ctx, tx,
+			infras.AuditChange{
+				Action:     infras.AuditCreate,
+				EntityType: "goods_receipt",
+				EntityID:   receipt.ID,
+				After:      receipt,
+			},
+			infras.AuditChange{
+				Action:     infras.AuditUpdate,
+				EntityType: "purchase_order",
+				EntityID:   po.ID,
+				Before:     before,
+				After:      po,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		return infras.PublishEvents(ctx, tx, model.GoodsReceived{
+			GoodsReceiptID:  receipt.ID,
+			PurchaseOrderID: po.ID,
+			Status:          po.Status,
+		})
+	})
+	return po, err
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/sanika-farm/sanika-farm-be/infras"
+
+// PurchasingRepository is the interface for repository.
+type PurchasingRepository interface {
+	SupplierRepository
+	PurchaseOrderRepository
+	GoodsReceiptRepository
+	SupplierInvoiceRepository
+}
+
+type PurchasingRepositoryImpl struct {
+	DB *infras.PostgresConn
+}
+
+func ProvidePurchasingRepository(db *infras.PostgresConn) *PurchasingRepositoryImpl {
+	return &PurchasingRepositoryImpl{
+		DB: db,
+	}
+}
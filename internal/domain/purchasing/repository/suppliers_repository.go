@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+)
+
+var (
+	createSupplier = struct {
+		Query string
+	}{
+		Query: `INSERT INTO suppliers (name, contact_name, phone, email, address)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at`,
+	}
+
+	listSuppliers = struct {
+		Query string
+	}{
+		Query: `SELECT id, name, contact_name, phone, email, address, created_at
+			FROM suppliers
+			ORDER BY name`,
+	}
+)
+
+type SupplierRepository interface {
+	CreateSupplier(ctx context.Context, supplier *model.Supplier) error
+	ListSuppliers(ctx context.Context) ([]model.Supplier, error)
+}
+
+func (r *PurchasingRepositoryImpl) CreateSupplier(ctx context.Context, supplier *model.Supplier) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		err := tx.QueryRowxContext(ctx, createSupplier.Query,
+			supplier.Name,
+			supplier.ContactName,
+			supplier.Phone,
+			supplier.Email,
+			supplier.Address,
+		).Scan(&supplier.ID, &supplier.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditCreate,
+			EntityType: "supplier",
+			EntityID:   supplier.ID,
+			After:      supplier,
+		})
+	})
+}
+
+func (r *PurchasingRepositoryImpl) ListSuppliers(ctx context.Context) ([]model.Supplier, error) {
+	suppliers := []model.Supplier{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &suppliers, listSuppliers.Query)
+	return suppliers, err
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(ctx context.Context, req *dto.CreatePurchaseOrderRequest) (dto.PurchaseOrderResponse, error)
+	GetPurchaseOrder(ctx context.Context, id int) (dto.PurchaseOrderResponse, error)
+	SendPurchaseOrder(ctx context.Context, id int) (dto.PurchaseOrderResponse, error)
+	ClosePurchaseOrder(ctx context.Context, id int) (dto.PurchaseOrderResponse, error)
+	ReceiveGoods(ctx context.Context, id int, req *dto.ReceiveGoodsRequest) (dto.PurchaseOrderResponse, error)
+	CreateSupplierInvoice(ctx context.Context, id int, req *dto.CreateSupplierInvoiceRequest) (dto.SupplierInvoiceResponse, error)
+}
+
+func (s PurchasingServiceImpl) CreatePurchaseOrder(ctx context.Context, req *dto.CreatePurchaseOrderRequest) (dto.PurchaseOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.PurchaseOrderResponse{}, failure.BadRequest(err)
+	}
+
+	po := req.ToModel()
+	if err := s.PurchasingRepository.CreatePurchaseOrder(ctx, &po); err != nil {
+		return dto.PurchaseOrderResponse{}, wrapError(ctx, err, "Failed to create purchase order")
+	}
+	return dto.NewPurchaseOrderResponse(po), nil
+}
+
+func (s PurchasingServiceImpl) GetPurchaseOrder(ctx context.Context, id int) (dto.PurchaseOrderResponse, error) {
+	po, err := s.PurchasingRepository.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, wrapError(ctx, err, "Failed to get purchase order")
+	}
+	return dto.NewPurchaseOrderResponse(po), nil
+}
+
+// SendPurchaseOrder marks a draft purchase order as sent to the supplier.
+func (s PurchasingServiceImpl) SendPurchaseOrder(ctx context.Context, id int) (dto.PurchaseOrderResponse, error) {
+	po, err := s.PurchasingRepository.TransitionPurchaseOrder(ctx, id, model.PurchaseOrderStatusSent)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, wrapError(ctx, err, "Failed to send purchase order")
+	}
+	return dto.NewPurchaseOrderResponse(po), nil
+}
+
+// ClosePurchaseOrder closes a fully received purchase order.
+func (s PurchasingServiceImpl) ClosePurchaseOrder(ctx context.Context, id int) (dto.PurchaseOrderResponse, error) {
+	po, err := s.PurchasingRepository.TransitionPurchaseOrder(ctx, id, model.PurchaseOrderStatusClosed)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, wrapError(ctx, err, "Failed to close purchase order")
+	}
+	return dto.NewPurchaseOrderResponse(po), nil
+}
+
+func (s PurchasingServiceImpl) ReceiveGoods(ctx context.Context, id int, req *dto.ReceiveGoodsRequest) (dto.PurchaseOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.PurchaseOrderResponse{}, failure.BadRequest(err)
+	}
+
+	receipt := req.ToModel(id)
+	po, err := s.PurchasingRepository.ReceiveGoods(ctx, &receipt)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, wrapError(ctx, err, "Failed to receive goods")
+	}
+	return dto.NewPurchaseOrderResponse(po), nil
+}
+
+func (s PurchasingServiceImpl) CreateSupplierInvoice(ctx context.Context, id int, req *dto.CreateSupplierInvoiceRequest) (dto.SupplierInvoiceResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.SupplierInvoiceResponse{}, failure.BadRequest(err)
+	}
+
+	invoice := req.ToModel(id)
+	if err := s.PurchasingRepository.CreateSupplierInvoice(ctx, &invoice); err != nil {
+		return dto.SupplierInvoiceResponse{}, wrapError(ctx, err, "Failed to create supplier invoice")
+	}
+	return dto.NewSupplierInvoiceResponse(invoice), nil
+}
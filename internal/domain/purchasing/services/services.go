@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type PurchasingService interface {
+	SupplierService
+	PurchaseOrderService
+}
+
+type PurchasingServiceImpl struct {
+	PurchasingRepository repository.PurchasingRepository
+	cfg                  *configs.Config
+}
+
+func ProvidePurchasingService(repo repository.PurchasingRepository, cfg *configs.Config) *PurchasingServiceImpl {
+	return &PurchasingServiceImpl{
+		PurchasingRepository: repo,
+		cfg:                  cfg,
+	}
+}
+
+// wrapError logs the error and turns unexpected errors into internal errors,
+// leaving failures raised by the domain untouched.
+func wrapError(ctx context.Context, err error, msg string) error {
+	if _, ok := err.(*failure.Failure); ok {
+		logger.FromContext(ctx).Warn().Err(err).Msg(msg)
+		return err
+	}
+	logger.FromContext(ctx).Error().Err(err).Msg(msg)
+	return failure.InternalError(err)
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+type SupplierService interface {
+	CreateSupplier(ctx context.Context, req *dto.CreateSupplierRequest) (model.Supplier, error)
+	ListSuppliers(ctx context.Context) ([]model.Supplier, error)
+}
+
+func (s PurchasingServiceImpl) CreateSupplier(ctx context.Context, req *dto.CreateSupplierRequest) (model.Supplier, error) {
+	if err := req.Validate(); err != nil {
+		return model.Supplier{}, failure.BadRequest(err)
+	}
+
+	supplier := req.ToModel()
+	if err := s.PurchasingRepository.CreateSupplier(ctx, &supplier); err != nil {
+		return model.Supplier{}, wrapError(ctx, err, "Failed to create supplier")
+	}
+	return supplier, nil
+}
+
+func (s PurchasingServiceImpl) ListSuppliers(ctx context.Context) ([]model.Supplier, error) {
+	suppliers, err := s.PurchasingRepository.ListSuppliers(ctx)
+	if err != nil {
+		return nil, wrapError(ctx, err, "Failed to list suppliers")
+	}
+	return suppliers, nil
+}
@@ -1,6 +1,14 @@
 package dto
 
-import "github.com/sanika-farm/sanika-farm-be/internal/domain/users/model"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
 
 type CreateUserRequest struct {
 	Username string `json:"username"`
@@ -8,6 +16,31 @@ type CreateUserRequest struct {
 	RoleID   int    `json:"roleId"`
 }
 
+// Password lengths accepted on registration. bcrypt only uses the first 72
+// bytes of a password.
+const (
+	minPasswordLength = 8
+	maxPasswordBytes  = 72
+)
+
+// Validate checks the request and trims the username.
+func (r *CreateUserRequest) Validate() error {
+	r.Username = strings.TrimSpace(r.Username)
+	if r.Username == "" {
+		return errors.New("username is required")
+	}
+	if len([]rune(r.Password)) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	if len(r.Password) > maxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes", maxPasswordBytes)
+	}
+	if r.RoleID <= 0 {
+		return errors.New("roleId is required")
+	}
+	return nil
+}
+
 func (r *CreateUserRequest) ToModel() model.User {
 	return model.User{
 		Username: r.Username,
@@ -15,3 +48,57 @@ func (r *CreateUserRequest) ToModel() model.User {
 		RoleID:   r.RoleID,
 	}
 }
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Validate checks that both credentials are given.
+func (r *LoginRequest) Validate() error {
+	r.Username = strings.TrimSpace(r.Username)
+	if r.Username == "" || r.Password == "" {
+		return errors.New("username and password are required")
+	}
+	return nil
+}
+
+// LoginResponse holds the access token of a logged in user. Clients send it
+// in the Authorization header as "Bearer <token>".
+type LoginResponse struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+	User      UserResponse `json:"user"`
+}
+
+// ListUsersResource is the allow-list of fields the users list can be sorted
+// and filtered by.
+var ListUsersResource = pagination.Resource{
+	Columns: map[string]string{
+		"id":       "id",
+		"username": "username",
+		"roleId":   "roleid",
+	},
+	Sortable: []string{"id", "username"},
+	Filterable: map[string]pagination.FieldType{
+		"username": pagination.String,
+		"roleId":   pagination.Int,
+	},
+	DefaultSort: "id",
+	CursorField: "id",
+	CursorType:  pagination.Int,
+}
+
+type UserResponse struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	RoleID   int    `json:"roleId"`
+}
+
+func NewUserResponse(u model.User) UserResponse {
+	return UserResponse{
+		ID:       u.ID,
+		Username: u.Username,
+		RoleID:   u.RoleID,
+	}
+}
@@ -0,0 +1,10 @@
+package model
+
+// UserRegistered is published when a user is created.
+type UserRegistered struct {
+	UserID   int    `json:"userId"`
+	Username string `json:"username"`
+	RoleID   int    `json:"roleId"`
+}
+
+func (UserRegistered) EventName() string { return "user.registered" }
@@ -2,33 +2,120 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
+	"github.com/sanika-farm/sanika-farm-be/infras"
 	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
 )
 
 var (
 	createUsers = struct {
 		Query string
 	}{
-		Query: `INSERT INTO users (username, password, roleId) VALUES (?, ?, ?)`,
+		Query: `INSERT INTO users (username, password, roleId) VALUES ($1, $2, $3) RETURNING id`,
+	}
+
+	getUser = struct {
+		Query string
+	}{
+		Query: `SELECT id, username, roleId AS "roleId" FROM users WHERE id = $1`,
+	}
+
+	// getUserByUsername also returns the password hash, for logins.
+	getUserByUsername = struct {
+		Query string
+	}{
+		Query: `SELECT id, username, password, roleId AS "roleId" FROM users WHERE username = $1`,
+	}
+
+	listUsers = struct {
+		Query      string
+		CountQuery string
+	}{
+		Query:      `SELECT id, username, roleId AS "roleId" FROM users`,
+		CountQuery: `SELECT COUNT(*) FROM users`,
 	}
 )
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *model.User) error
+	GetUser(ctx context.Context, id int) (model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (model.User, error)
+	ListUsers(ctx context.Context, params pagination.Params) ([]model.User, int, error)
 }
 
 func (r *UsersRepositoryImpl) CreateUser(ctx context.Context, user *model.User) error {
-	tx, err := r.DB.Write.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		err := tx.QueryRowxContext(ctx, createUsers.Query, user.Username, user.Password, user.RoleID).Scan(&user.ID)
+		if infras.IsUniqueViolation(err) {
+			return failure.Conflict("register", "user", "username is already taken")
+		}
+		if err != nil {
+			return err
+		}
+
+		// The password hash is left out of the audit record.
+		err = infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditCreate,
+			EntityType: "user",
+			EntityID:   user.ID,
+			After: map[string]interface{}{
+				"id":       user.ID,
+				"username": user.Username,
+				"roleId":   user.RoleID,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return infras.PublishEvents(ctx, tx, model.UserRegistered{
+			UserID:   user.ID,
+			Username: user.Username,
+			RoleID:   user.RoleID,
+		})
+	})
+}
+
+func (r *UsersRepositoryImpl) GetUser(ctx context.Context, id int) (model.User, error) {
+	var user model.User
+	err := r.DB.Reader(ctx).GetContext(ctx, &user, getUser.Query, id)
+	if err == sql.ErrNoRows {
+		return user, failure.NotFound("user")
 	}
+	return user, err
+}
 
-	_, err = tx.Exec(createUsers.Query, user.Username, user.Password, user.RoleID)
+// GetUserByUsername resolves a user with their password hash by username.
+func (r *UsersRepositoryImpl) GetUserByUsername(ctx context.Context, username string) (model.User, error) {
+	var user model.User
+	err := r.DB.Reader(ctx).GetContext(ctx, &user, getUserByUsername.Query, username)
+	if err == sql.ErrNoRows {
+		return user, failure.NotFound("user")
+	}
+	return user, err
+}
+
+// ListUsers returns one page of users. The total is only counted for offset
+// pagination and is zero for cursor pages.
+func (r *UsersRepositoryImpl) ListUsers(ctx context.Context, params pagination.Params) ([]model.User, int, error) {
+	where, args := params.Where()
+	limit, limitArgs := params.LimitOffset(len(args))
+
+	users := []model.User{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &users, listUsers.Query+where+params.OrderBy()+limit, append(args, limitArgs...)...)
 	if err != nil {
-		tx.Rollback()
-		return err
+		return nil, 0, err
 	}
 
-	return tx.Commit()
+	var total int
+	if !params.IsCursor() {
+		countWhere, countArgs := params.CountWhere()
+		if err = r.DB.Reader(ctx).GetContext(ctx, &total, listUsers.CountQuery+countWhere, countArgs...); err != nil {
+			return nil, 0, err
+		}
+	}
+	return users, total, nil
 }
@@ -3,6 +3,9 @@ package services
 import (
 	"github.com/sanika-farm/sanika-farm-be/configs"
 	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/cache"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+	"github.com/sanika-farm/sanika-farm-be/pkg/token"
 )
 
 type UsersService interface {
@@ -12,11 +15,17 @@ type UsersService interface {
 type UsersServiceImpl struct {
 	UsersRepository repository.UsersRepository
 	cfg             *configs.Config
+	cache           *cache.Cache
+	tokens          *token.Issuer
+	usersRegistered *metrics.Counter
 }
 
-func ProvideUsersService(repo repository.UsersRepository, cfg *configs.Config) *UsersServiceImpl {
+func ProvideUsersService(repo repository.UsersRepository, cfg *configs.Config, cache *cache.Cache, tokens *token.Issuer, registry *metrics.Registry) *UsersServiceImpl {
 	return &UsersServiceImpl{
 		UsersRepository: repo,
 		cfg:             cfg,
+		cache:           cache,
+		tokens:          tokens,
+		usersRegistered: registry.NewCounter("users_registered_total", "Total number of registered users."),
 	}
 }
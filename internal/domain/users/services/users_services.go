@@ -2,26 +2,162 @@ package services
 
 import (
 	"context"
+	"strconv"
+	"sync"
+	"time"
 
-	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model"
 	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/cache"
 	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	userCacheTTL     = 5 * time.Minute
+	userListCacheTTL = time.Minute
+	userCacheTag     = "users"
+)
+
+func userCacheKey(id int) string {
+	return "user:" + strconv.Itoa(id)
+}
+
+func userListCacheKey(params pagination.Params) string {
+	return "users:" + params.CacheKey()
+}
+
+// userPage is a cached page of ListUsers.
+type userPage struct {
+	Users    []dto.UserResponse  `json:"users"`
+	Metadata pagination.Metadata `json:"metadata"`
+}
+
+// errInvalidCredentials answers every failed login alike, so logins do not
+// reveal which usernames exist.
+var errInvalidCredentials = failure.Unauthorized("invalid username or password")
+
+// dummyHash is compared against when a login names an unknown user, so it
+// takes as long as a wrong password.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not a password"), bcrypt.DefaultCost)
+	return hash
+})
+
 type UserService interface {
-	CreateUser(ctx context.Context, req *dto.CreateUserRequest) error
+	CreateUser(ctx context.Context, req *dto.CreateUserRequest) (dto.UserResponse, error)
+	Login(ctx context.Context, req *dto.LoginRequest) (dto.LoginResponse, error)
+	GetUser(ctx context.Context, id int) (dto.UserResponse, error)
+	ListUsers(ctx context.Context, params pagination.Params) ([]dto.UserResponse, pagination.Metadata, error)
 }
 
-func (s UsersServiceImpl) CreateUser(ctx context.Context, req *dto.CreateUserRequest) error {
+// CreateUser registers a user, storing a bcrypt hash of the password.
+func (s UsersServiceImpl) CreateUser(ctx context.Context, req *dto.CreateUserRequest) (dto.UserResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.UserResponse{}, failure.BadRequest(err)
+	}
+
 	user := req.ToModel()
-	err := s.UsersRepository.CreateUser(ctx, &user)
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to hash password")
+		return dto.UserResponse{}, failure.InternalError(err)
+	}
+	user.Password = string(hash)
+
+	err = s.UsersRepository.CreateUser(ctx, &user)
+	if err != nil {
+		if failure.GetCode(err) >= 500 {
+			logger.FromContext(ctx).Error().Err(err).Msg("Failed to create user")
+			return dto.UserResponse{}, failure.InternalError(err)
+		}
+		logger.FromContext(ctx).Warn().Err(err).Msg("Failed to create user")
+		return dto.UserResponse{}, err
+	}
+	s.usersRegistered.Inc()
+
+	// Cached user lists no longer include every user.
+	if err := s.cache.Invalidate(ctx, userCacheTag); err != nil {
+		logger.FromContext(ctx).Warn().Err(err).Msg("Failed to invalidate cached users")
+	}
+	return dto.NewUserResponse(user), nil
+}
+
+// Login checks a username and password and issues an access token. Unknown
+// users and wrong passwords both fail as unauthorized.
+func (s UsersServiceImpl) Login(ctx context.Context, req *dto.LoginRequest) (dto.LoginResponse, error) {
+	if err := req.Validate(); err != nil {
+		return dto.LoginResponse{}, failure.BadRequest(err)
+	}
+
+	user, err := s.UsersRepository.GetUserByUsername(ctx, req.Username)
 	if err != nil {
 		if failure.GetCode(err) >= 500 {
-			log.Error().Err(err).Msgf("Failed to create user: %v", err)
-			return err
+			logger.FromContext(ctx).Error().Err(err).Msg("Failed to get user")
+			return dto.LoginResponse{}, failure.InternalError(err)
 		}
-		log.Warn().Err(err).Msgf("Failed to create user: %v", err)
-		return err
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(req.Password))
+		return dto.LoginResponse{}, errInvalidCredentials
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		logger.FromContext(ctx).Warn().Int("userId", user.ID).Msg("Failed login")
+		return dto.LoginResponse{}, errInvalidCredentials
+	}
+
+	signed, expiresAt, err := s.tokens.Issue(user.ID, user.RoleID)
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to issue token")
+		return dto.LoginResponse{}, failure.InternalError(err)
+	}
+	return dto.LoginResponse{Token: signed, ExpiresAt: expiresAt, User: dto.NewUserResponse(user)}, nil
+}
+
+// GetUser resolves a user by ID through the cache.
+func (s UsersServiceImpl) GetUser(ctx context.Context, id int) (dto.UserResponse, error) {
+	res, err := cache.GetOrLoad(ctx, s.cache, userCacheKey(id), cache.Options{TTL: userCacheTTL, Tags: []string{userCacheTag}},
+		func(ctx context.Context) (dto.UserResponse, error) {
+			user, err := s.UsersRepository.GetUser(ctx, id)
+			if err != nil {
+				return dto.UserResponse{}, err
+			}
+			return dto.NewUserResponse(user), nil
+		})
+	if err != nil {
+		if failure.GetCode(err) >= 500 {
+			logger.FromContext(ctx).Error().Err(err).Int("id", id).Msg("Failed to get user")
+			return dto.UserResponse{}, failure.InternalError(err)
+		}
+		return dto.UserResponse{}, err
+	}
+	return res, nil
+}
+
+// ListUsers lists users through the cache. Pages are dropped whenever a user
+// is created.
+func (s UsersServiceImpl) ListUsers(ctx context.Context, params pagination.Params) ([]dto.UserResponse, pagination.Metadata, error) {
+	page, err := cache.GetOrLoad(ctx, s.cache, userListCacheKey(params), cache.Options{TTL: userListCacheTTL, Tags: []string{userCacheTag}},
+		func(ctx context.Context) (userPage, error) {
+			users, total, err := s.UsersRepository.ListUsers(ctx, params)
+			if err != nil {
+				return userPage{}, err
+			}
+
+			users, metadata := pagination.Page(params, users, total, func(u model.User) string {
+				return strconv.Itoa(u.ID)
+			})
+
+			res := make([]dto.UserResponse, 0, len(users))
+			for _, u := range users {
+				res = append(res, dto.NewUserResponse(u))
+			}
+			return userPage{Users: res, Metadata: metadata}, nil
+		})
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Msg("Failed to list users")
+		return nil, pagination.Metadata{}, failure.InternalError(err)
 	}
-	return nil
+	return page.Users, page.Metadata, nil
 }
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/cache"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+	"github.com/sanika-farm/sanika-farm-be/pkg/token"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeUsers keeps users in memory with unique usernames, like the users
+// table.
+type fakeUsers struct {
+	repository.UsersRepository
+	users map[string]model.User
+}
+
+func (f *fakeUsers) CreateUser(_ context.Context, user *model.User) error {
+	if _, ok := f.users[user.Username]; ok {
+		return failure.Conflict("register", "user", "username is already taken")
+	}
+	user.ID = len(f.users) + 1
+	f.users[user.Username] = *user
+	return nil
+}
+
+func (f *fakeUsers) GetUserByUsername(_ context.Context, username string) (model.User, error) {
+	user, ok := f.users[username]
+	if !ok {
+		return model.User{}, failure.NotFound("user")
+	}
+	return user, nil
+}
+
+var testTokens = token.NewIssuer([]byte(strings.Repeat("s", 32)), "farm", time.Hour)
+
+func newTestService() (UsersServiceImpl, *fakeUsers) {
+	repo := &fakeUsers{users: map[string]model.User{}}
+	return *ProvideUsersService(repo, nil, cache.New(nil), testTokens, metrics.NewRegistry()), repo
+}
+
+func TestCreateUserHashesPassword(t *testing.T) {
+	s, repo := newTestService()
+	ctx := context.Background()
+
+	res, err := s.CreateUser(ctx, &dto.CreateUserRequest{Username: " budi ", Password: "correct horse", RoleID: 1})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if res.ID == 0 || res.Username != "budi" {
+		t.Errorf("CreateUser = %+v, want budi with an ID", res)
+	}
+
+	stored := repo.users["budi"].Password
+	if stored == "correct horse" {
+		t.Fatal("password was stored in plain text")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte("correct horse")); err != nil {
+		t.Errorf("stored hash does not match the password: %v", err)
+	}
+
+	_, err = s.CreateUser(ctx, &dto.CreateUserRequest{Username: "budi", Password: "another one", RoleID: 1})
+	if code := failure.GetCode(err); code != http.StatusConflict {
+		t.Errorf("registering a taken username failed with %d (%v), want 409", code, err)
+	}
+}
+
+func TestCreateUserValidates(t *testing.T) {
+	s, _ := newTestService()
+	for _, req := range []dto.CreateUserRequest{
+		{Username: "", Password: "correct horse", RoleID: 1},
+		{Username: "budi", Password: "short", RoleID: 1},
+		{Username: "budi", Password: "correct horse"},
+	} {
+		if _, err := s.CreateUser(context.Background(), &req); failure.GetCode(err) != http.StatusBadRequest {
+			t.Errorf("CreateUser(%+v) = %v, want 400", req, err)
+		}
+	}
+}
+
+func TestLogin(t *testing.T) {
+	s, _ := newTestService()
+	ctx := context.Background()
+	if _, err := s.CreateUser(ctx, &dto.CreateUserRequest{Username: "budi", Password: "correct horse", RoleID: 2}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	res, err := s.Login(ctx, &dto.LoginRequest{Username: "budi", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if res.User.Username != "budi" || res.User.RoleID != 2 {
+		t.Errorf("Login = %+v, want budi", res.User)
+	}
+	claims, err := testTokens.Verify(res.Token)
+	if err != nil {
+		t.Fatalf("Verify login token: %v", err)
+	}
+	if claims.UserID != res.User.ID || claims.RoleID != 2 || !claims.ExpiresAt.Equal(res.ExpiresAt) {
+		t.Errorf("token claims = %+v, want user %d with role 2 expiring at %v", claims, res.User.ID, res.ExpiresAt)
+	}
+
+	tests := []struct {
+		name string
+		req  dto.LoginRequest
+		want int
+	}{
+		{"wrong password", dto.LoginRequest{Username: "budi", Password: "wrong horse"}, http.StatusUnauthorized},
+		{"unknown user", dto.LoginRequest{Username: "siti", Password: "correct horse"}, http.StatusUnauthorized},
+		{"missing password", dto.LoginRequest{Username: "budi"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Login(ctx, &tt.req); failure.GetCode(err) != tt.want {
+				t.Errorf("Login = %v, want %d", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package dto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/netguard"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+// minSecretLength is the shortest secret accepted from clients.
+const minSecretLength = 16
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	// Secret signs the deliveries. One is generated when it is left empty.
+	Secret string `json:"secret"`
+}
+
+// Validate checks the request. It resolves the URL's host, so ctx should be
+// the request's.
+func (r *CreateWebhookRequest) Validate(ctx context.Context) error {
+	return validateWebhook(ctx, r.URL, r.EventTypes, r.Secret)
+}
+
+func (r *CreateWebhookRequest) ToModel() model.Subscription {
+	return model.Subscription{
+		URL:        strings.TrimSpace(r.URL),
+		EventTypes: trimEventTypes(r.EventTypes),
+		Secret:     r.Secret,
+		Active:     true,
+	}
+}
+
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Active     bool     `json:"active"`
+	// Secret replaces the signing secret. The current one is kept when it is
+	// left empty.
+	Secret string `json:"secret"`
+}
+
+// Validate checks the request. It resolves the URL's host, so ctx should be
+// the request's.
+func (r *UpdateWebhookRequest) Validate(ctx context.Context) error {
+	return validateWebhook(ctx, r.URL, r.EventTypes, r.Secret)
+}
+
+func (r *UpdateWebhookRequest) ToModel(id int) model.Subscription {
+	return model.Subscription{
+		ID:         id,
+		URL:        strings.TrimSpace(r.URL),
+		EventTypes: trimEventTypes(r.EventTypes),
+		Secret:     r.Secret,
+		Active:     r.Active,
+	}
+}
+
+func validateWebhook(ctx context.Context, rawURL string, eventTypes []string, secret string) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	// The dispatcher checks the address again when it connects, in case the
+	// host is later pointed elsewhere. CheckHost bounds the lookup with its
+	// own timeout.
+	if err := netguard.CheckHost(ctx, u.Hostname()); err != nil {
+		return fmt.Errorf("url must point to a public address: %w", err)
+	}
+	if len(eventTypes) == 0 {
+		return errors.New("eventTypes must not be empty")
+	}
+	for _, t := range eventTypes {
+		name := strings.TrimSpace(t)
+		if name == "" {
+			return errors.New("eventTypes must not contain empty names")
+		}
+		if !model.IsEventName(name) {
+			return fmt.Errorf("eventTypes contains unknown event %q, want %q or one of %s", name, model.AllEvents, strings.Join(model.EventNames, ", "))
+		}
+	}
+	if secret != "" && len(secret) < minSecretLength {
+		return errors.New("secret must be at least 16 characters")
+	}
+	return nil
+}
+
+func trimEventTypes(eventTypes []string) model.EventTypes {
+	res := make(model.EventTypes, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		res = append(res, strings.TrimSpace(t))
+	}
+	return res
+}
+
+type WebhookResponse struct {
+	ID         int       `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func NewWebhookResponse(s model.Subscription) WebhookResponse {
+	return WebhookResponse{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+// CreateWebhookResponse is the only response that includes the secret.
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+// ListWebhookDeliveriesResource is the allow-list of fields the delivery log
+// can be sorted and filtered by.
+var ListWebhookDeliveriesResource = pagination.Resource{
+	Columns: map[string]string{
+		"id":        "id",
+		"webhookId": "subscription_id",
+		"event":     "event_name",
+		"status":    "status",
+		"createdAt": "created_at",
+	},
+	Sortable: []string{"id", "createdAt"},
+	Filterable: map[string]pagination.FieldType{
+		"webhookId": pagination.Int,
+		"event":     pagination.String,
+		"status":    pagination.String,
+		"createdAt": pagination.Time,
+	},
+	DefaultSort: "-id",
+	CursorField: "id",
+	CursorType:  pagination.Int,
+}
+
+type DeliveryAttemptResponse struct {
+	Attempt     int       `json:"attempt"`
+	StatusCode  *int      `json:"statusCode"`
+	Error       *string   `json:"error"`
+	DurationMs  int64     `json:"durationMs"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+}
+
+type DeliveryResponse struct {
+	ID             int64                     `json:"id"`
+	WebhookID      int                       `json:"webhookId"`
+	EventID        int64                     `json:"eventId"`
+	Event          string                    `json:"event"`
+	Status         model.DeliveryStatus      `json:"status"`
+	Attempts       int                       `json:"attempts"`
+	NextAttemptAt  *time.Time                `json:"nextAttemptAt"`
+	LastStatusCode *int                      `json:"lastStatusCode"`
+	LastError      *string                   `json:"lastError"`
+	DeliveredAt    *time.Time                `json:"deliveredAt"`
+	CreatedAt      time.Time                 `json:"createdAt"`
+	AttemptLog     []DeliveryAttemptResponse `json:"attemptLog,omitempty"`
+}
+
+func NewDeliveryResponse(d model.Delivery) DeliveryResponse {
+	res := DeliveryResponse{
+		ID:             d.ID,
+		WebhookID:      d.SubscriptionID,
+		EventID:        d.EventID,
+		Event:          d.EventName,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == model.DeliveryStatusPending {
+		next := d.NextAttemptAt
+		res.NextAttemptAt = &next
+	}
+	for _, a := range d.AttemptLog {
+		res.AttemptLog = append(res.AttemptLog, DeliveryAttemptResponse{
+			Attempt:     a.Attempt,
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+			DurationMs:  a.DurationMs,
+			AttemptedAt: a.AttemptedAt,
+		})
+	}
+	return res
+}
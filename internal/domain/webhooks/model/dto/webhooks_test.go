@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sanika-farm/sanika-farm-be/pkg/netguard"
+)
+
+func TestValidateWebhookRejectsInternalAddresses(t *testing.T) {
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.20/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[fe80::1]/hook",
+	} {
+		req := CreateWebhookRequest{URL: url, EventTypes: []string{"*"}}
+		if err := req.Validate(context.Background()); !errors.Is(err, netguard.ErrForbiddenAddress) {
+			t.Errorf("Validate(%s) = %v, want ErrForbiddenAddress", url, err)
+		}
+	}
+
+	req := CreateWebhookRequest{URL: "https://93.184.216.34/hook", EventTypes: []string{"*"}}
+	if err := req.Validate(context.Background()); err != nil {
+		t.Errorf("Validate of a public address = %v, want nil", err)
+	}
+}
+
+func TestValidateWebhookEventTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventTypes []string
+		want       string
+	}{
+		{"every event", []string{"*"}, ""},
+		{"published events", []string{"mortality.recorded", " user.registered "}, ""},
+		{"none", nil, "eventTypes must not be empty"},
+		{"empty name", []string{"mortality.recorded", " "}, "eventTypes must not contain empty names"},
+		{"unknown event", []string{"animal.died"}, `eventTypes contains unknown event "animal.died"`},
+		{"wrong case", []string{"Mortality.Recorded"}, `eventTypes contains unknown event "Mortality.Recorded"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := UpdateWebhookRequest{URL: "https://93.184.216.34/hook", EventTypes: tt.eventTypes}
+			err := req.Validate(context.Background())
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("Validate = %v, want an error starting with %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookResolvesWithTheRequestContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := CreateWebhookRequest{URL: "https://hooks.example.com/farm", EventTypes: []string{"*"}}
+	if err := req.Validate(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Validate with a cancelled context = %v, want context.Canceled", err)
+	}
+}
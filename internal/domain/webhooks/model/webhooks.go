@@ -0,0 +1,154 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	mortalityModel "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model"
+	processingModel "github.com/sanika-farm/sanika-farm-be/internal/domain/processing/model"
+	purchasingModel "github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model"
+	usersModel "github.com/sanika-farm/sanika-farm-be/internal/domain/users/model"
+)
+
+// AllEvents subscribes a webhook to every event.
+const AllEvents = "*"
+
+// EventNames are the names of the published events a webhook can subscribe
+// to. An event added to a domain must be listed here before clients can
+// subscribe to it by name.
+var EventNames = []string{
+	mortalityModel.MortalityRecorded{}.EventName(),
+	processingModel.ProcessingBatchRecorded{}.EventName(),
+	purchasingModel.GoodsReceived{}.EventName(),
+	purchasingModel.SupplierInvoiceRecorded{}.EventName(),
+	usersModel.UserRegistered{}.EventName(),
+}
+
+// IsEventName reports whether name is AllEvents or one of EventNames.
+func IsEventName(name string) bool {
+	return name == AllEvents || slices.Contains(EventNames, name)
+}
+
+// Headers sent with every webhook delivery.
+const (
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Subscription is a URL notified of the events it subscribes to. The secret
+// signs every delivery and is only shown when the subscription is created.
+type Subscription struct {
+	ID         int        `db:"id"`
+	URL        string     `db:"url"`
+	EventTypes EventTypes `db:"event_types"`
+	Secret     string     `db:"secret" json:"-"`
+	Active     bool       `db:"active"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+// EventTypes is the list of event names a subscription receives, stored as
+// a JSON array.
+type EventTypes []string
+
+// Value implements driver.Valuer.
+func (t EventTypes) Value() (driver.Value, error) {
+	if t == nil {
+		t = EventTypes{}
+	}
+	b, err := json.Marshal([]string(t))
+	return string(b), err
+}
+
+// Scan implements sql.Scanner.
+func (t *EventTypes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(t))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(t))
+	case nil:
+		*t = nil
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into EventTypes", src)
+}
+
+// DeliveryStatus is the state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	// DeliveryStatusDead is a delivery that failed every attempt. It is only
+	// retried when replayed.
+	DeliveryStatusDead DeliveryStatus = "dead"
+)
+
+// Delivery is one event to be posted to one subscription.
+type Delivery struct {
+	ID             int64             `db:"id"`
+	SubscriptionID int               `db:"subscription_id"`
+	EventID        int64             `db:"event_id"`
+	EventName      string            `db:"event_name"`
+	Payload        json.RawMessage   `db:"payload"`
+	OccurredAt     time.Time         `db:"occurred_at"`
+	Status         DeliveryStatus    `db:"status"`
+	Attempts       int               `db:"attempts"`
+	NextAttemptAt  time.Time         `db:"next_attempt_at"`
+	LastStatusCode *int              `db:"last_status_code"`
+	LastError      *string           `db:"last_error"`
+	DeliveredAt    *time.Time        `db:"delivered_at"`
+	CreatedAt      time.Time         `db:"created_at"`
+	AttemptLog     []DeliveryAttempt `db:"-"`
+}
+
+// PendingDelivery is a delivery claimed for sending, with the subscription
+// it goes to.
+type PendingDelivery struct {
+	Delivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// DeliveryAttempt is the outcome of one attempt to post a delivery.
+type DeliveryAttempt struct {
+	ID          int64     `db:"id"`
+	DeliveryID  int64     `db:"delivery_id"`
+	Attempt     int       `db:"attempt"`
+	StatusCode  *int      `db:"status_code"`
+	Error       *string   `db:"error"`
+	DurationMs  int64     `db:"duration_ms"`
+	AttemptedAt time.Time `db:"attempted_at"`
+}
+
+// Body returns the JSON posted for the delivery. The id is the event's, so
+// receivers can discard retries and replays they have already handled.
+func (d Delivery) Body() ([]byte, error) {
+	return json.Marshal(struct {
+		ID         int64           `json:"id"`
+		Event      string          `json:"event"`
+		OccurredAt time.Time       `json:"occurredAt"`
+		Data       json.RawMessage `json:"data"`
+	}{d.EventID, d.EventName, d.OccurredAt, d.Payload})
+}
+
+// Sign returns the signature sent in SignatureHeader: the hex HMAC-SHA256,
+// keyed with the subscription secret, of the timestamp, a dot and the body.
+// Receivers should recompute it and reject old timestamps to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/events"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+const deliveryColumns = `id, subscription_id, event_id, event_name, payload, occurred_at, status, attempts,
+	next_attempt_at, last_status_code, last_error, delivered_at, created_at`
+
+var (
+	// enqueueDeliveries creates one delivery per active subscription to the
+	// event. The unique (subscription_id, event_id) key makes it safe to run
+	// again when the outbox delivers the event twice.
+	enqueueDeliveries = struct {
+		Query string
+	}{
+		Query: `INSERT INTO webhook_deliveries (subscription_id, event_id, event_name, payload, occurred_at, status, next_attempt_at)
+			SELECT id, $1, $2, $3, $4, 'pending', NOW()
+			FROM webhook_subscriptions
+			WHERE active AND (event_types @> jsonb_build_array($2::text) OR event_types @> '["*"]')
+			ON CONFLICT (subscription_id, event_id) DO NOTHING`,
+	}
+
+	// claimDeliveries leases due deliveries by pushing next_attempt_at past
+	// the lease, so a dispatcher that dies mid-request does not lose them.
+	claimDeliveries = struct {
+		Query string
+	}{
+		Query: `WITH claimed AS (
+				UPDATE webhook_deliveries
+				SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $2)
+				WHERE id IN (
+					SELECT id FROM webhook_deliveries
+					WHERE status = 'pending' AND next_attempt_at <= NOW()
+					ORDER BY id
+					LIMIT $1
+					FOR UPDATE SKIP LOCKED
+				)
+				RETURNING ` + deliveryColumns + `
+			)
+			SELECT c.*, s.url, s.secret
+			FROM claimed c
+			JOIN webhook_subscriptions s ON s.id = c.subscription_id`,
+	}
+
+	recordDeliveryAttempt = struct {
+		AttemptQuery  string
+		DeliveryQuery string
+	}{
+		AttemptQuery: `INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms, attempted_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+		DeliveryQuery: `UPDATE webhook_deliveries
+			SET status = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5, delivered_at = $6
+			WHERE id = $1`,
+	}
+
+	getDelivery = struct {
+		Query         string
+		AttemptsQuery string
+	}{
+		Query: `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`,
+		AttemptsQuery: `SELECT id, delivery_id, attempt, status_code, error, duration_ms, attempted_at
+			FROM webhook_delivery_attempts
+			WHERE delivery_id = $1
+			ORDER BY attempted_at, id`,
+	}
+
+	listDeliveries = struct {
+		Query      string
+		CountQuery string
+	}{
+		Query:      `SELECT ` + deliveryColumns + ` FROM webhook_deliveries`,
+		CountQuery: `SELECT COUNT(*) FROM webhook_deliveries`,
+	}
+
+	replayDelivery = struct {
+		Query string
+	}{
+		Query: `UPDATE webhook_deliveries
+			SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+			WHERE id = $1
+			RETURNING ` + deliveryColumns,
+	}
+)
+
+type DeliveryRepository interface {
+	EnqueueDeliveries(ctx context.Context, msg events.Message) (int64, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.PendingDelivery, error)
+	RecordDeliveryAttempt(ctx context.Context, delivery model.Delivery, attempt model.DeliveryAttempt) error
+	GetDelivery(ctx context.Context, id int64) (model.Delivery, error)
+	ListDeliveries(ctx context.Context, params pagination.Params) ([]model.Delivery, int, error)
+	ReplayDelivery(ctx context.Context, id int64) (model.Delivery, error)
+}
+
+// EnqueueDeliveries creates the deliveries of an event and returns how many
+// were created.
+func (r *WebhooksRepositoryImpl) EnqueueDeliveries(ctx context.Context, msg events.Message) (int64, error) {
+	res, err := r.DB.Writer(ctx).ExecContext(ctx, enqueueDeliveries.Query,
+		msg.ID,
+		msg.Name,
+		string(msg.Payload),
+		msg.OccurredAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// ClaimDeliveries claims up to limit due deliveries for lease, counting the
+// claim as an attempt.
+func (r *WebhooksRepositoryImpl) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.PendingDelivery, error) {
+	deliveries := []model.PendingDelivery{}
+	err := r.DB.Writer(ctx).SelectContext(ctx, &deliveries, claimDeliveries.Query, limit, lease.Seconds())
+	return deliveries, err
+}
+
+// RecordDeliveryAttempt logs an attempt and stores the delivery's resulting
+// status in one transaction.
+func (r *WebhooksRepositoryImpl) RecordDeliveryAttempt(ctx context.Context, delivery model.Delivery, attempt model.DeliveryAttempt) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		_, err := tx.ExecContext(ctx, recordDeliveryAttempt.AttemptQuery,
+			attempt.DeliveryID,
+			attempt.Attempt,
+			attempt.StatusCode,
+			attempt.Error,
+			attempt.DurationMs,
+			attempt.AttemptedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, recordDeliveryAttempt.DeliveryQuery,
+			delivery.ID,
+			delivery.Status,
+			delivery.NextAttemptAt,
+			delivery.LastStatusCode,
+			delivery.LastError,
+			delivery.DeliveredAt,
+		)
+		return err
+	})
+}
+
+// GetDelivery returns a delivery with its attempt log.
+func (r *WebhooksRepositoryImpl) GetDelivery(ctx context.Context, id int64) (model.Delivery, error) {
+	var delivery model.Delivery
+	err := r.DB.Reader(ctx).GetContext(ctx, &delivery, getDelivery.Query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return delivery, failure.NotFound("webhook delivery")
+		}
+		return delivery, err
+	}
+
+	err = r.DB.Reader(ctx).SelectContext(ctx, &delivery.AttemptLog, getDelivery.AttemptsQuery, id)
+	return delivery, err
+}
+
+// ListDeliveries returns one page of the delivery log. The total is only
+// counted for offset pagination and is zero for cursor pages.
+func (r *WebhooksRepositoryImpl) ListDeliveries(ctx context.Context, params pagination.Params) ([]model.Delivery, int, error) {
+	where, args := params.Where()
+	limit, limitArgs := params.LimitOffset(len(args))
+
+	deliveries := []model.Delivery{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &deliveries, listDeliveries.Query+where+params.OrderBy()+limit, append(args, limitArgs...)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if !params.IsCursor() {
+		countWhere, countArgs := params.CountWhere()
+		if err = r.DB.Reader(ctx).GetContext(ctx, &total, listDeliveries.CountQuery+countWhere, countArgs...); err != nil {
+			return nil, 0, err
+		}
+	}
+	return deliveries, total, nil
+}
+
+// ReplayDelivery queues a delivery to be sent again now with a fresh set of
+// attempts, whatever its status. Earlier attempts stay in the log.
+func (r *WebhooksRepositoryImpl) ReplayDelivery(ctx context.Context, id int64) (model.Delivery, error) {
+	var delivery model.Delivery
+	err := r.DB.Writer(ctx).GetContext(ctx, &delivery, replayDelivery.Query, id)
+	if err == sql.ErrNoRows {
+		return delivery, failure.NotFound("webhook delivery")
+	}
+	return delivery, err
+}
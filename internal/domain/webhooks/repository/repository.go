@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/sanika-farm/sanika-farm-be/infras"
+
+// WebhooksRepository is the interface for repository.
+type WebhooksRepository interface {
+	SubscriptionRepository
+	DeliveryRepository
+}
+
+type WebhooksRepositoryImpl struct {
+	DB *infras.PostgresConn
+}
+
+func ProvideWebhooksRepository(db *infras.PostgresConn) *WebhooksRepositoryImpl {
+	return &WebhooksRepositoryImpl{
+		DB: db,
+	}
+}
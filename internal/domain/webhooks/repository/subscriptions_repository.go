@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var (
+	createSubscription = struct {
+		Query string
+	}{
+		Query: `INSERT INTO webhook_subscriptions (url, event_types, secret, active)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at, updated_at`,
+	}
+
+	getSubscription = struct {
+		Query          string
+		ForUpdateQuery string
+	}{
+		Query: `SELECT id, url, event_types, secret, active, created_at, updated_at
+			FROM webhook_subscriptions
+			WHERE id = $1`,
+		ForUpdateQuery: `SELECT id, url, event_types, secret, active, created_at, updated_at
+			FROM webhook_subscriptions
+			WHERE id = $1
+			FOR UPDATE`,
+	}
+
+	listSubscriptions = struct {
+		Query string
+	}{
+		Query: `SELECT id, url, event_types, secret, active, created_at, updated_at
+			FROM webhook_subscriptions
+			ORDER BY id`,
+	}
+
+	updateSubscription = struct {
+		Query string
+	}{
+		Query: `UPDATE webhook_subscriptions
+			SET url = $2, event_types = $3, secret = $4, active = $5, updated_at = NOW()
+			WHERE id = $1
+			RETURNING updated_at`,
+	}
+
+	deleteSubscription = struct {
+		Query string
+	}{
+		Query: `DELETE FROM webhook_subscriptions WHERE id = $1`,
+	}
+)
+
+type SubscriptionRepository interface {
+	CreateSubscription(ctx context.Context, sub *model.Subscription) error
+	GetSubscription(ctx context.Context, id int) (model.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]model.Subscription, error)
+	UpdateSubscription(ctx context.Context, sub *model.Subscription) error
+	DeleteSubscription(ctx context.Context, id int) error
+}
+
+func (r *WebhooksRepositoryImpl) CreateSubscription(ctx context.Context, sub *model.Subscription) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		err := tx.QueryRowxContext(ctx, createSubscription.Query,
+			sub.URL,
+			sub.EventTypes,
+			sub.Secret,
+			sub.Active,
+		).Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditCreate,
+			EntityType: "webhook_subscription",
+			EntityID:   sub.ID,
+			After:      sub,
+		})
+	})
+}
+
+func (r *WebhooksRepositoryImpl) GetSubscription(ctx context.Context, id int) (model.Subscription, error) {
+	var sub model.Subscription
+	err := r.DB.Reader(ctx).GetContext(ctx, &sub, getSubscription.Query, id)
+	if err == sql.ErrNoRows {
+		return sub, failure.NotFound("webhook")
+	}
+	return sub, err
+}
+
+func (r *WebhooksRepositoryImpl) ListSubscriptions(ctx context.Context) ([]model.Subscription, error) {
+	subs := []model.Subscription{}
+	err := r.DB.Reader(ctx).SelectContext(ctx, &subs, listSubscriptions.Query)
+	return subs, err
+}
+
+// UpdateSubscription replaces the subscription's settings. An empty secret
+// keeps the current one.
+func (r *WebhooksRepositoryImpl) UpdateSubscription(ctx context.Context, sub *model.Subscription) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		before, err := r.getSubscriptionForUpdate(ctx, tx, sub.ID)
+		if err != nil {
+			return err
+		}
+		if sub.Secret == "" {
+			sub.Secret = before.Secret
+		}
+		sub.CreatedAt = before.CreatedAt
+
+		err = tx.QueryRowxContext(ctx, updateSubscription.Query,
+			sub.ID,
+			sub.URL,
+			sub.EventTypes,
+			sub.Secret,
+			sub.Active,
+		).Scan(&sub.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditUpdate,
+			EntityType: "webhook_subscription",
+			EntityID:   sub.ID,
+			Before:     before,
+			After:      sub,
+		})
+	})
+}
+
+// DeleteSubscription removes the subscription together with its delivery log.
+func (r *WebhooksRepositoryImpl) DeleteSubscription(ctx context.Context, id int) error {
+	return r.DB.RunInTx(ctx, nil, func(ctx context.Context, tx *infras.Tx) error {
+		before, err := r.getSubscriptionForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, deleteSubscription.Query, id); err != nil {
+			return err
+		}
+
+		return infras.RecordAudit(ctx, tx, infras.AuditChange{
+			Action:     infras.AuditDelete,
+			EntityType: "webhook_subscription",
+			EntityID:   id,
+			Before:     before,
+		})
+	})
+}
+
+func (r *WebhooksRepositoryImpl) getSubscriptionForUpdate(ctx context.Context, tx *infras.Tx, id int) (model.Subscription, error) {
+	var sub model.Subscription
+	err := tx.GetContext(ctx, &sub, getSubscription.ForUpdateQuery, id)
+	if err == sql.ErrNoRows {
+		return sub, failure.NotFound("webhook")
+	}
+	return sub, err
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/events"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+)
+
+type DeliveryService interface {
+	ListDeliveries(ctx context.Context, params pagination.Params) ([]dto.DeliveryResponse, pagination.Metadata, error)
+	GetDelivery(ctx context.Context, id int64) (dto.DeliveryResponse, error)
+	ReplayDelivery(ctx context.Context, id int64) (dto.DeliveryResponse, error)
+}
+
+// enqueueDeliveries queues a delivery of the event to every subscription
+// that wants it. The dispatcher sends them.
+func (s WebhooksServiceImpl) enqueueDeliveries(ctx context.Context, msg events.Message) error {
+	n, err := s.WebhooksRepository.EnqueueDeliveries(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		logger.FromContext(ctx).Debug().Int64("eventId", msg.ID).Str("event", msg.Name).Int64("deliveries", n).Msg("Queued webhook deliveries.")
+	}
+	return nil
+}
+
+func (s WebhooksServiceImpl) ListDeliveries(ctx context.Context, params pagination.Params) ([]dto.DeliveryResponse, pagination.Metadata, error) {
+	deliveries, total, err := s.WebhooksRepository.ListDeliveries(ctx, params)
+	if err != nil {
+		return nil, pagination.Metadata{}, wrapError(ctx, err, "Failed to list webhook deliveries")
+	}
+
+	deliveries, metadata := pagination.Page(params, deliveries, total, func(d model.Delivery) string {
+		return strconv.FormatInt(d.ID, 10)
+	})
+
+	res := make([]dto.DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, dto.NewDeliveryResponse(d))
+	}
+	return res, metadata, nil
+}
+
+// GetDelivery returns a delivery with the log of its attempts.
+func (s WebhooksServiceImpl) GetDelivery(ctx context.Context, id int64) (dto.DeliveryResponse, error) {
+	delivery, err := s.WebhooksRepository.GetDelivery(ctx, id)
+	if err != nil {
+		return dto.DeliveryResponse{}, wrapError(ctx, err, "Failed to get webhook delivery")
+	}
+	return dto.NewDeliveryResponse(delivery), nil
+}
+
+// ReplayDelivery sends a delivery again, including dead and succeeded ones.
+func (s WebhooksServiceImpl) ReplayDelivery(ctx context.Context, id int64) (dto.DeliveryResponse, error) {
+	delivery, err := s.WebhooksRepository.ReplayDelivery(ctx, id)
+	if err != nil {
+		return dto.DeliveryResponse{}, wrapError(ctx, err, "Failed to replay webhook delivery")
+	}
+	logger.FromContext(ctx).Info().Int64("deliveryId", id).Msg("Webhook delivery queued for replay.")
+	return dto.NewDeliveryResponse(delivery), nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+	"github.com/sanika-farm/sanika-farm-be/pkg/netguard"
+)
+
+// maxResponseBody is how much of a receiver's response is read before the
+// connection is released.
+const maxResponseBody = 64 << 10
+
+// dialTimeout bounds connecting to a receiver.
+const dialTimeout = 5 * time.Second
+
+// Dispatcher posts queued webhook deliveries to their subscriptions. A
+// delivery succeeds on any 2xx response; anything else, including
+// redirects and timeouts, is retried with exponential backoff until the
+// configured number of attempts, after which the delivery is dead.
+// Receivers on internal addresses are refused when connecting.
+type Dispatcher struct {
+	repo   repository.WebhooksRepository
+	cfg    *configs.Config
+	client *http.Client
+
+	deliveries *metrics.Counter
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// ProvideWebhookDispatcher is the provider for the webhook dispatcher. It
+// does not send anything until Start is called.
+func ProvideWebhookDispatcher(repo repository.WebhooksRepository, cfg *configs.Config, registry *metrics.Registry) *Dispatcher {
+	return &Dispatcher{
+		repo: repo,
+		cfg:  cfg,
+		client: &http.Client{
+			Timeout:   cfg.Webhooks.Timeout,
+			Transport: guardedTransport(),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		deliveries: registry.NewCounter("webhook_deliveries_total", "Total number of webhook delivery attempts by outcome.", "event", "outcome"),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// guardedTransport connects only to public addresses. It uses no proxy, as
+// the guard would then only see the proxy's address.
+func guardedTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   netguard.Control,
+	}).DialContext
+	return transport
+}
+
+// Start sends due deliveries in the background until Shutdown.
+func (d *Dispatcher) Start() {
+	if d.started.CompareAndSwap(false, true) {
+		go d.run()
+	}
+}
+
+// Shutdown stops sending and waits for the delivery in progress to finish.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+	if !d.started.Load() {
+		return nil
+	}
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+	ticker := time.NewTicker(d.cfg.Webhooks.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for d.dispatchBatch() == d.cfg.Webhooks.BatchSize {
+			select {
+			case <-d.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// dispatchBatch claims and sends one batch of due deliveries. It returns
+// the number of deliveries claimed.
+func (d *Dispatcher) dispatchBatch() int {
+	ctx := infras.WithPrimary(context.Background())
+
+	// The lease outlasts the request timeout, so a delivery is not claimed
+	// again while it is still being sent.
+	lease := 2 * d.cfg.Webhooks.Timeout
+	pending, err := d.repo.ClaimDeliveries(ctx, d.cfg.Webhooks.BatchSize, lease)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim webhook deliveries.")
+		return 0
+	}
+
+	for _, p := range pending {
+		select {
+		case <-d.stop:
+			// Unsent deliveries are claimed again once their lease ends.
+			return len(pending)
+		default:
+		}
+		d.send(ctx, p)
+	}
+	return len(pending)
+}
+
+func (d *Dispatcher) send(ctx context.Context, p model.PendingDelivery) {
+	delivery := p.Delivery
+	attempt := model.DeliveryAttempt{
+		DeliveryID:  delivery.ID,
+		Attempt:     delivery.Attempts,
+		AttemptedAt: time.Now(),
+	}
+	statusCode, err := d.post(ctx, p)
+	attempt.DurationMs = time.Since(attempt.AttemptedAt).Milliseconds()
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+	if err != nil {
+		msg := err.Error()
+		attempt.Error = &msg
+	}
+
+	deliveryLog := log.With().Int64("deliveryId", delivery.ID).Int("webhookId", delivery.SubscriptionID).Str("event", delivery.EventName).Int("attempt", delivery.Attempts).Logger()
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+	switch {
+	case err == nil:
+		delivery.Status = model.DeliveryStatusSucceeded
+		delivery.DeliveredAt = &attempt.AttemptedAt
+		d.deliveries.Inc(delivery.EventName, "succeeded")
+	case delivery.Attempts >= d.cfg.Webhooks.MaxAttempts:
+		delivery.Status = model.DeliveryStatusDead
+		d.deliveries.Inc(delivery.EventName, "dead")
+		deliveryLog.Error().Err(err).Msg("Webhook delivery failed every attempt; giving up until replayed.")
+	default:
+		delay := infras.RetryDelay(d.cfg.Webhooks.InitialBackoff, d.cfg.Webhooks.MaxBackoff, delivery.Attempts)
+		delivery.Status = model.DeliveryStatusPending
+		delivery.NextAttemptAt = time.Now().Add(delay)
+		d.deliveries.Inc(delivery.EventName, "retry")
+		deliveryLog.Warn().Err(err).Dur("retryIn", delay).Msg("Webhook delivery failed, retrying.")
+	}
+
+	if err := d.repo.RecordDeliveryAttempt(ctx, delivery, attempt); err != nil {
+		deliveryLog.Error().Err(err).Msg("Failed to record webhook delivery attempt; it will be sent again.")
+	}
+}
+
+// post sends the delivery and returns the response status code, which is
+// zero if no response was received.
+func (d *Dispatcher) post(ctx context.Context, p model.PendingDelivery) (int, error) {
+	body, err := p.Body()
+	if err != nil {
+		return 0, fmt.Errorf("encoding body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", d.cfg.App.Name+"-webhooks")
+	req.Header.Set(model.DeliveryHeader, strconv.FormatInt(p.ID, 10))
+	req.Header.Set(model.EventHeader, p.EventName)
+	req.Header.Set(model.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(model.SignatureHeader, model.Sign(p.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+)
+
+const testSecret = "0123456789abcdef0123"
+
+// fakeDeliveries keeps deliveries in memory, claiming and recording them the
+// way the Postgres repository does.
+type fakeDeliveries struct {
+	repository.WebhooksRepository
+
+	mu         sync.Mutex
+	deliveries map[int64]*model.PendingDelivery
+	attempts   []model.DeliveryAttempt
+}
+
+func newFakeDeliveries(url string, deliveries ...model.Delivery) *fakeDeliveries {
+	f := &fakeDeliveries{deliveries: make(map[int64]*model.PendingDelivery)}
+	for _, d := range deliveries {
+		f.deliveries[d.ID] = &model.PendingDelivery{Delivery: d, URL: url, Secret: testSecret}
+	}
+	return f
+}
+
+func (f *fakeDeliveries) ClaimDeliveries(_ context.Context, limit int, lease time.Duration) ([]model.PendingDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var claimed []model.PendingDelivery
+	for _, p := range f.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if p.Status != model.DeliveryStatusPending || p.NextAttemptAt.After(time.Now()) {
+			continue
+		}
+		p.Attempts++
+		p.NextAttemptAt = time.Now().Add(lease)
+		claimed = append(claimed, *p)
+	}
+	return claimed, nil
+}
+
+func (f *fakeDeliveries) RecordDeliveryAttempt(_ context.Context, delivery model.Delivery, attempt model.DeliveryAttempt) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts = append(f.attempts, attempt)
+	p := f.deliveries[delivery.ID]
+	p.Status = delivery.Status
+	p.NextAttemptAt = delivery.NextAttemptAt
+	p.LastStatusCode = delivery.LastStatusCode
+	p.LastError = delivery.LastError
+	p.DeliveredAt = delivery.DeliveredAt
+	return nil
+}
+
+func (f *fakeDeliveries) ReplayDelivery(_ context.Context, id int64) (model.Delivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.deliveries[id]
+	if !ok {
+		return model.Delivery{}, failure.NotFound("webhook delivery")
+	}
+	p.Status = model.DeliveryStatusPending
+	p.Attempts = 0
+	p.NextAttemptAt = time.Now()
+	p.DeliveredAt = nil
+	return p.Delivery, nil
+}
+
+func (f *fakeDeliveries) get(id int64) model.PendingDelivery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.deliveries[id]
+}
+
+// receiver is a webhook receiver answering with the queued status codes, and
+// 204 once they run out.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	status := http.StatusNoContent
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.requests)
+}
+
+func testConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.App.Name = "farm"
+	cfg.Webhooks.BatchSize = 10
+	cfg.Webhooks.Timeout = 5 * time.Second
+	cfg.Webhooks.MaxAttempts = 3
+	cfg.Webhooks.InitialBackoff = time.Minute
+	cfg.Webhooks.MaxBackoff = time.Hour
+	return cfg
+}
+
+func testDelivery(id int64) model.Delivery {
+	return model.Delivery{
+		ID:             id,
+		SubscriptionID: 1,
+		EventID:        42,
+		EventName:      "mortality.recorded",
+		Payload:        json.RawMessage(`{"animalId":7}`),
+		OccurredAt:     time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+		Status:         model.DeliveryStatusPending,
+		NextAttemptAt:  time.Now().Add(-time.Second),
+	}
+}
+
+// newTestDispatcher returns a dispatcher posting to a local receiver. The
+// guarded transport refuses loopback addresses, so the test server's
+// transport is used instead.
+func newTestDispatcher(t *testing.T, rc *receiver, deliveries ...model.Delivery) (*Dispatcher, *fakeDeliveries, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(rc)
+	t.Cleanup(srv.Close)
+
+	repo := newFakeDeliveries(srv.URL, deliveries...)
+	d := ProvideWebhookDispatcher(repo, testConfig(), metrics.NewRegistry())
+	d.client.Transport = srv.Client().Transport
+	return d, repo, srv
+}
+
+func TestDispatcherSignsDeliveries(t *testing.T) {
+	rc := &receiver{}
+	d, repo, _ := newTestDispatcher(t, rc, testDelivery(1))
+
+	if n := d.dispatchBatch(); n != 1 {
+		t.Fatalf("dispatchBatch claimed %d deliveries, want 1", n)
+	}
+	if rc.count() != 1 {
+		t.Fatalf("receiver got %d requests, want 1", rc.count())
+	}
+
+	req, body := rc.requests[0], rc.bodies[0]
+	timestamp, err := strconv.ParseInt(req.Header.Get(model.TimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("bad %s header: %v", model.TimestampHeader, err)
+	}
+	if got, want := req.Header.Get(model.SignatureHeader), model.Sign(testSecret, timestamp, body); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if got := req.Header.Get(model.DeliveryHeader); got != "1" {
+		t.Errorf("%s = %q, want 1", model.DeliveryHeader, got)
+	}
+	if got := req.Header.Get(model.EventHeader); got != "mortality.recorded" {
+		t.Errorf("%s = %q, want mortality.recorded", model.EventHeader, got)
+	}
+	want, _ := testDelivery(1).Body()
+	if string(body) != string(want) {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+
+	delivered := repo.get(1)
+	if delivered.Status != model.DeliveryStatusSucceeded || delivered.DeliveredAt == nil {
+		t.Errorf("delivery = %s delivered at %v, want succeeded", delivered.Status, delivered.DeliveredAt)
+	}
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	rc := &receiver{statuses: []int{http.StatusInternalServerError}}
+	d, repo, _ := newTestDispatcher(t, rc, testDelivery(1))
+	cfg := testConfig()
+
+	before := time.Now()
+	d.dispatchBatch()
+	after := time.Now()
+
+	delivery := repo.get(1)
+	if delivery.Status != model.DeliveryStatusPending {
+		t.Fatalf("status = %s, want pending", delivery.Status)
+	}
+	delay := infras.RetryDelay(cfg.Webhooks.InitialBackoff, cfg.Webhooks.MaxBackoff, 1)
+	if delivery.NextAttemptAt.Before(before.Add(delay)) || delivery.NextAttemptAt.After(after.Add(delay)) {
+		t.Errorf("next attempt in %v, want %v", delivery.NextAttemptAt.Sub(before), delay)
+	}
+	if delivery.LastStatusCode == nil || *delivery.LastStatusCode != http.StatusInternalServerError {
+		t.Errorf("last status code = %v, want 500", delivery.LastStatusCode)
+	}
+	if len(repo.attempts) != 1 || repo.attempts[0].Error == nil {
+		t.Errorf("attempts = %+v, want one failed attempt", repo.attempts)
+	}
+
+	// The delivery is not due again until the backoff has passed.
+	if n := d.dispatchBatch(); n != 0 {
+		t.Errorf("dispatchBatch claimed %d deliveries during the backoff, want 0", n)
+	}
+}
+
+func TestDispatcherMarksDeliveryDeadAfterMaxAttempts(t *testing.T) {
+	cfg := testConfig()
+	statuses := make([]int, cfg.Webhooks.MaxAttempts)
+	for i := range statuses {
+		statuses[i] = http.StatusBadGateway
+	}
+	rc := &receiver{statuses: statuses}
+	d, repo, _ := newTestDispatcher(t, rc, testDelivery(1))
+
+	for i := 1; i <= cfg.Webhooks.MaxAttempts; i++ {
+		d.dispatchBatch()
+		delivery := repo.get(1)
+		if i < cfg.Webhooks.MaxAttempts {
+			if delivery.Status != model.DeliveryStatusPending {
+				t.Fatalf("status after attempt %d = %s, want pending", i, delivery.Status)
+			}
+			// Skip the backoff.
+			repo.mu.Lock()
+			repo.deliveries[1].NextAttemptAt = time.Now().Add(-time.Second)
+			repo.mu.Unlock()
+		}
+	}
+
+	if status := repo.get(1).Status; status != model.DeliveryStatusDead {
+		t.Fatalf("status after %d attempts = %s, want dead", cfg.Webhooks.MaxAttempts, status)
+	}
+	if n := d.dispatchBatch(); n != 0 || rc.count() != cfg.Webhooks.MaxAttempts {
+		t.Errorf("dead delivery was sent again: claimed %d, %d requests", n, rc.count())
+	}
+}
+
+func TestReplaySendsDeliveryAgain(t *testing.T) {
+	dead := testDelivery(1)
+	dead.Status = model.DeliveryStatusDead
+	dead.Attempts = 3
+	rc := &receiver{}
+	d, repo, _ := newTestDispatcher(t, rc, dead)
+	service := WebhooksServiceImpl{WebhooksRepository: repo, cfg: testConfig()}
+
+	if n := d.dispatchBatch(); n != 0 {
+		t.Fatalf("dispatchBatch claimed a dead delivery")
+	}
+
+	res, err := service.ReplayDelivery(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ReplayDelivery: %v", err)
+	}
+	if res.Status != model.DeliveryStatusPending || res.Attempts != 0 {
+		t.Errorf("replayed delivery = %s with %d attempts, want pending with 0", res.Status, res.Attempts)
+	}
+
+	d.dispatchBatch()
+	if rc.count() != 1 {
+		t.Fatalf("receiver got %d requests after replay, want 1", rc.count())
+	}
+	if got := rc.requests[0].Header.Get(model.DeliveryHeader); got != "1" {
+		t.Errorf("%s = %q, want the replayed delivery", model.DeliveryHeader, got)
+	}
+	if status := repo.get(1).Status; status != model.DeliveryStatusSucceeded {
+		t.Errorf("status after replay = %s, want succeeded", status)
+	}
+}
+
+func TestDispatcherRefusesInternalReceivers(t *testing.T) {
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	repo := newFakeDeliveries(srv.URL, testDelivery(1))
+	d := ProvideWebhookDispatcher(repo, testConfig(), metrics.NewRegistry())
+	d.dispatchBatch()
+
+	if rc.count() != 0 {
+		t.Errorf("loopback receiver got %d requests, want 0", rc.count())
+	}
+	if delivery := repo.get(1); delivery.Status != model.DeliveryStatusPending || delivery.LastError == nil {
+		t.Errorf("delivery = %s with error %v, want a failed attempt", delivery.Status, delivery.LastError)
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/repository"
+	"github.com/sanika-farm/sanika-farm-be/pkg/events"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+)
+
+type WebhooksService interface {
+	SubscriptionService
+	DeliveryService
+}
+
+type WebhooksServiceImpl struct {
+	WebhooksRepository repository.WebhooksRepository
+	cfg                *configs.Config
+}
+
+// ProvideWebhooksService is the provider for the webhooks service. It
+// subscribes to every event on the bus to queue the matching deliveries.
+func ProvideWebhooksService(repo repository.WebhooksRepository, cfg *configs.Config, bus *events.Bus) *WebhooksServiceImpl {
+	s := &WebhooksServiceImpl{
+		WebhooksRepository: repo,
+		cfg:                cfg,
+	}
+	bus.SubscribeAll("webhooks", s.enqueueDeliveries)
+	return s
+}
+
+// wrapError logs the error and turns unexpected errors into internal errors,
+// leaving failures raised by the domain untouched.
+func wrapError(ctx context.Context, err error, msg string) error {
+	if _, ok := err.(*failure.Failure); ok {
+		logger.FromContext(ctx).Warn().Err(err).Msg(msg)
+		return err
+	}
+	logger.FromContext(ctx).Error().Err(err).Msg(msg)
+	return failure.InternalError(err)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+type SubscriptionService interface {
+	CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (dto.CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context) ([]dto.WebhookResponse, error)
+	GetWebhook(ctx context.Context, id int) (dto.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, id int, req *dto.UpdateWebhookRequest) (dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id int) error
+}
+
+// CreateWebhook subscribes a URL to events. The response carries the signing
+// secret, which is not shown again.
+func (s WebhooksServiceImpl) CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (dto.CreateWebhookResponse, error) {
+	if err := req.Validate(ctx); err != nil {
+		return dto.CreateWebhookResponse{}, failure.BadRequest(err)
+	}
+
+	sub := req.ToModel()
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return dto.CreateWebhookResponse{}, wrapError(ctx, err, "Failed to generate webhook secret")
+		}
+		sub.Secret = secret
+	}
+
+	if err := s.WebhooksRepository.CreateSubscription(ctx, &sub); err != nil {
+		return dto.CreateWebhookResponse{}, wrapError(ctx, err, "Failed to create webhook")
+	}
+	return dto.CreateWebhookResponse{
+		WebhookResponse: dto.NewWebhookResponse(sub),
+		Secret:          sub.Secret,
+	}, nil
+}
+
+func (s WebhooksServiceImpl) ListWebhooks(ctx context.Context) ([]dto.WebhookResponse, error) {
+	subs, err := s.WebhooksRepository.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, wrapError(ctx, err, "Failed to list webhooks")
+	}
+
+	res := make([]dto.WebhookResponse, 0, len(subs))
+	for _, sub := range subs {
+		res = append(res, dto.NewWebhookResponse(sub))
+	}
+	return res, nil
+}
+
+func (s WebhooksServiceImpl) GetWebhook(ctx context.Context, id int) (dto.WebhookResponse, error) {
+	sub, err := s.WebhooksRepository.GetSubscription(ctx, id)
+	if err != nil {
+		return dto.WebhookResponse{}, wrapError(ctx, err, "Failed to get webhook")
+	}
+	return dto.NewWebhookResponse(sub), nil
+}
+
+func (s WebhooksServiceImpl) UpdateWebhook(ctx context.Context, id int, req *dto.UpdateWebhookRequest) (dto.WebhookResponse, error) {
+	if err := req.Validate(ctx); err != nil {
+		return dto.WebhookResponse{}, failure.BadRequest(err)
+	}
+
+	sub := req.ToModel(id)
+	if err := s.WebhooksRepository.UpdateSubscription(ctx, &sub); err != nil {
+		return dto.WebhookResponse{}, wrapError(ctx, err, "Failed to update webhook")
+	}
+	return dto.NewWebhookResponse(sub), nil
+}
+
+func (s WebhooksServiceImpl) DeleteWebhook(ctx context.Context, id int) error {
+	if err := s.WebhooksRepository.DeleteSubscription(ctx, id); err != nil {
+		return wrapError(ctx, err, "Failed to delete webhook")
+	}
+	return nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/audit/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// ListAuditEntries lists the audit trail.
+// @Summary List audit entries.
+// @Description This endpoint lists recorded data changes, newest first, with offset (page, limit) or cursor (after, limit) pagination. It needs the administrator role.
+// @Tags audit
+// @Param entity query string false "Filter by entity type, e.g. purchase_order."
+// @Param id query string false "Filter by the ID of the changed entity."
+// @Param action query string false "Filter by action: create, update or delete."
+// @Param actorUserId query int false "Filter by the ID of the user who made the change."
+// @Param page query int false "Page number, starting at 1."
+// @Param limit query int false "Page size, at most 100."
+// @Param after query string false "Cursor from a previous page's nextCursor. Only valid when sorting by auditId."
+// @Param sort query string false "Comma-separated fields to sort by, prefixed with - for descending: auditId, createdAt."
+// @Produce json
+// @Success 200 {object} response.Base{data=[]dto.AuditEntryResponse,metadata=pagination.Metadata}
+// @Failure 400 {object} response.Base
+// @Failure 401 {object} response.Base
+// @Failure 403 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/audit [get]
+func (h *AuditHandler) ListAuditEntries(c *gin.Context) {
+	params, err := pagination.Parse(c, dto.ListAuditResource)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	res, metadata, err := h.AuditService.ListAuditEntries(c.Request.Context(), params)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithMetadata(c, http.StatusOK, res, metadata)
+}
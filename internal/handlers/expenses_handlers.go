@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// CreateExpense records a new Expense and its enterprise allocations.
+// @Summary Record a new Expense.
+// @Description This endpoint records an Expense allocated to one or more enterprises. Allocations must add up to the expense amount.
+// @Tags expenses
+// @Param Expense body dto.CreateExpenseRequest true "The Expense to be recorded."
+// @Produce json
+// @Success 201 {object} response.Base{data=dto.ExpenseResponse}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/expenses [post]
+func (h *ExpensesHandler) CreateExpense(c *gin.Context) {
+	var req dto.CreateExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.ExpensesService.CreateExpense(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// GetExpense resolves an Expense by its ID.
+// @Summary Get an Expense.
+// @Description This endpoint resolves an Expense and its allocations by ID.
+// @Tags expenses
+// @Param id path int true "The Expense ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.ExpenseResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/expenses/{id} [get]
+func (h *ExpensesHandler) GetExpense(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.ExpensesService.GetExpense(c.Request.Context(), id)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// CreateIncome records a new Income for an enterprise.
+// @Summary Record a new Income.
+// @Description This endpoint records an Income received by an enterprise.
+// @Tags expenses
+// @Param Income body dto.CreateIncomeRequest true "The Income to be recorded."
+// @Produce json
+// @Success 201 {object} response.Base{data=model.Income}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/incomes [post]
+func (h *ExpensesHandler) CreateIncome(c *gin.Context) {
+	var req dto.CreateIncomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.ExpensesService.CreateIncome(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// GetProfitability reports income, expense and profit per enterprise and period.
+// @Summary Get the profitability report.
+// @Description This endpoint combines income and expense totals per enterprise, bucketed by month, quarter or year.
+// @Tags expenses
+// @Param from query string true "Start date (inclusive), formatted as YYYY-MM-DD."
+// @Param to query string true "End date (exclusive), formatted as YYYY-MM-DD."
+// @Param period query string false "Bucket size: month, quarter or year. Defaults to month."
+// @Produce json
+// @Success 200 {object} response.Base{data=[]model.ProfitabilityRow,metadata=dto.ProfitabilityMetadata}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/reports/profitability [get]
+func (h *ExpensesHandler) GetProfitability(c *gin.Context) {
+	var req dto.ProfitabilityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	rows, metadata, err := h.ExpensesService.GetProfitability(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithMetadata(c, http.StatusOK, rows, metadata)
+}
@@ -2,7 +2,14 @@ package handlers
 
 import (
 	"github.com/gin-gonic/gin"
+	auditServices "github.com/sanika-farm/sanika-farm-be/internal/domain/audit/services"
+	expensesServices "github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/services"
+	mortalityServices "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/services"
+	notificationsServices "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/services"
+	processingServices "github.com/sanika-farm/sanika-farm-be/internal/domain/processing/services"
+	purchasingServices "github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/services"
 	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/services"
+	webhooksServices "github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/services"
 )
 
 // UsersHandler is the HTTP handler for Users domain.
@@ -17,9 +24,197 @@ func ProvideUsersHandler(svcUser services.UsersService) UsersHandler {
 	}
 }
 
-func (h *UsersHandler) Router(router *gin.RouterGroup) {
+// AuthRouter registers the routes that authenticate or create accounts. They
+// sit behind the stricter authentication rate limit.
+func (h *UsersHandler) AuthRouter(router *gin.RouterGroup) {
 	users := router.Group("/users")
 	{
 		users.POST("/register", h.CreateUser)
+		users.POST("/login", h.Login)
+	}
+}
+
+func (h *UsersHandler) Router(router *gin.RouterGroup) {
+	users := router.Group("/users")
+	{
+		users.GET("", h.ListUsers)
+		users.GET("/:id", h.GetUser)
+	}
+}
+
+// ExpensesHandler is the HTTP handler for Expenses domain.
+type ExpensesHandler struct {
+	ExpensesService expensesServices.ExpensesService
+}
+
+// ProvideExpensesHandler is the provider for this handler.
+func ProvideExpensesHandler(svcExpenses expensesServices.ExpensesService) ExpensesHandler {
+	return ExpensesHandler{
+		ExpensesService: svcExpenses,
+	}
+}
+
+func (h *ExpensesHandler) Router(router *gin.RouterGroup) {
+	expenses := router.Group("/expenses")
+	{
+		expenses.POST("", h.CreateExpense)
+		expenses.GET("/:id", h.GetExpense)
+	}
+
+	incomes := router.Group("/incomes")
+	{
+		incomes.POST("", h.CreateIncome)
+	}
+
+	reports := router.Group("/reports")
+	{
+		reports.GET("/profitability", h.GetProfitability)
+	}
+}
+
+// PurchasingHandler is the HTTP handler for Purchasing domain.
+type PurchasingHandler struct {
+	PurchasingService purchasingServices.PurchasingService
+}
+
+// ProvidePurchasingHandler is the provider for this handler.
+func ProvidePurchasingHandler(svcPurchasing purchasingServices.PurchasingService) PurchasingHandler {
+	return PurchasingHandler{
+		PurchasingService: svcPurchasing,
+	}
+}
+
+func (h *PurchasingHandler) Router(router *gin.RouterGroup) {
+	suppliers := router.Group("/suppliers")
+	{
+		suppliers.POST("", h.CreateSupplier)
+		suppliers.GET("", h.ListSuppliers)
+	}
+
+	purchaseOrders := router.Group("/purchase-orders")
+	{
+		purchaseOrders.POST("", h.CreatePurchaseOrder)
+		purchaseOrders.GET("/:id", h.GetPurchaseOrder)
+		purchaseOrders.POST("/:id/send", h.SendPurchaseOrder)
+		purchaseOrders.POST("/:id/receipts", h.ReceiveGoods)
+		purchaseOrders.POST("/:id/invoices", h.CreateSupplierInvoice)
+		purchaseOrders.POST("/:id/close", h.ClosePurchaseOrder)
+	}
+}
+
+// MortalityHandler is the HTTP handler for Mortality domain.
+type MortalityHandler struct {
+	MortalityService mortalityServices.MortalityService
+}
+
+// ProvideMortalityHandler is the provider for this handler.
+func ProvideMortalityHandler(svcMortality mortalityServices.MortalityService) MortalityHandler {
+	return MortalityHandler{
+		MortalityService: svcMortality,
+	}
+}
+
+func (h *MortalityHandler) Router(router *gin.RouterGroup) {
+	animals := router.Group("/animals")
+	{
+		animals.POST("/:id/mortality", h.RecordMortality)
+	}
+
+	reports := router.Group("/reports")
+	{
+		reports.GET("/mortality", h.GetMortalityReport)
+	}
+}
+
+// ProcessingHandler is the HTTP handler for Processing domain.
+type ProcessingHandler struct {
+	ProcessingService processingServices.ProcessingService
+}
+
+// ProvideProcessingHandler is the provider for this handler.
+func ProvideProcessingHandler(svcProcessing processingServices.ProcessingService) ProcessingHandler {
+	return ProcessingHandler{
+		ProcessingService: svcProcessing,
+	}
+}
+
+func (h *ProcessingHandler) Router(router *gin.RouterGroup) {
+	batches := router.Group("/processing-batches")
+	{
+		batches.POST("", h.CreateProcessingBatch)
+	}
+
+	trace := router.Group("/trace")
+	{
+		trace.GET("/:lotCode", h.GetTrace)
+	}
+}
+
+// AuditHandler is the HTTP handler for Audit domain.
+type AuditHandler struct {
+	AuditService auditServices.AuditService
+}
+
+// ProvideAuditHandler is the provider for this handler.
+func ProvideAuditHandler(svcAudit auditServices.AuditService) AuditHandler {
+	return AuditHandler{
+		AuditService: svcAudit,
+	}
+}
+
+func (h *AuditHandler) Router(router *gin.RouterGroup) {
+	router.GET("/audit", h.ListAuditEntries)
+}
+
+// WebhooksHandler is the HTTP handler for Webhooks domain.
+type WebhooksHandler struct {
+	WebhooksService webhooksServices.WebhooksService
+}
+
+// ProvideWebhooksHandler is the provider for this handler.
+func ProvideWebhooksHandler(svcWebhooks webhooksServices.WebhooksService) WebhooksHandler {
+	return WebhooksHandler{
+		WebhooksService: svcWebhooks,
+	}
+}
+
+func (h *WebhooksHandler) Router(router *gin.RouterGroup) {
+	webhooks := router.Group("/webhooks")
+	{
+		webhooks.POST("", h.CreateWebhook)
+		webhooks.GET("", h.ListWebhooks)
+		webhooks.GET("/:id", h.GetWebhook)
+		webhooks.PUT("/:id", h.UpdateWebhook)
+		webhooks.DELETE("/:id", h.DeleteWebhook)
+	}
+
+	deliveries := router.Group("/webhook-deliveries")
+	{
+		deliveries.GET("", h.ListWebhookDeliveries)
+		deliveries.GET("/:id", h.GetWebhookDelivery)
+		deliveries.POST("/:id/replay", h.ReplayWebhookDelivery)
+	}
+}
+
+// NotificationsHandler is the HTTP handler for Notifications domain.
+type NotificationsHandler struct {
+	NotificationsService notificationsServices.NotificationsService
+}
+
+// ProvideNotificationsHandler is the provider for this handler.
+func ProvideNotificationsHandler(svcNotifications notificationsServices.NotificationsService) NotificationsHandler {
+	return NotificationsHandler{
+		NotificationsService: svcNotifications,
+	}
+}
+
+func (h *NotificationsHandler) Router(router *gin.RouterGroup) {
+	notifications := router.Group("/notifications")
+	{
+		notifications.GET("", h.ListNotifications)
+		notifications.POST("/read", h.MarkAllNotificationsRead)
+		notifications.POST("/:id/read", h.MarkNotificationRead)
+		notifications.GET("/preferences", h.GetNotificationPreferences)
+		notifications.PUT("/preferences", h.UpdateNotificationPreferences)
 	}
 }
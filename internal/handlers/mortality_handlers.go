@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// RecordMortality records the death or culling of an Animal.
+// @Summary Record a death or cull.
+// @Description This endpoint records the death or culling of an active Animal and updates its status in the same transaction.
+// @Tags mortality
+// @Param id path int true "The Animal ID."
+// @Param MortalityRecord body dto.RecordMortalityRequest true "The death or cull to be recorded."
+// @Produce json
+// @Success 201 {object} response.Base{data=dto.MortalityRecordResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 409 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/animals/{id}/mortality [post]
+func (h *MortalityHandler) RecordMortality(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	var req dto.RecordMortalityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.MortalityService.RecordMortality(c.Request.Context(), id, &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// GetMortalityReport reports mortality rates by cause, pen, age band and month.
+// @Summary Get the mortality report.
+// @Description This endpoint reports deaths and culls per 100 head at risk, broken down by cause, pen, age band and month.
+// @Tags mortality
+// @Param from query string true "Start date (inclusive), formatted as YYYY-MM-DD."
+// @Param to query string true "End date (exclusive), formatted as YYYY-MM-DD."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.MortalityReport}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/reports/mortality [get]
+func (h *MortalityHandler) GetMortalityReport(c *gin.Context) {
+	var req dto.MortalityReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.MortalityService.GetMortalityReport(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
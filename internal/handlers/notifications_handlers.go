@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// ListNotifications lists the current user's in-app notifications.
+// @Summary List Notifications.
+// @Description This endpoint lists the in-app notifications of the current user, newest first, with offset (page, limit) or cursor (after, limit) pagination.
+// @Tags notifications
+// @Param type query string false "Filter by notification type, e.g. farm.low_feed."
+// @Param read query bool false "Filter by whether the notification has been read."
+// @Param page query int false "Page number, starting at 1."
+// @Param limit query int false "Page size, at most 100."
+// @Param after query string false "Cursor from a previous page's nextCursor. Only valid when sorting by id."
+// @Param sort query string false "Comma-separated fields to sort by, prefixed with - for descending: id, createdAt."
+// @Produce json
+// @Success 200 {object} response.Base{data=[]dto.NotificationResponse,metadata=pagination.Metadata}
+// @Failure 400 {object} response.Base
+// @Failure 401 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/notifications [get]
+func (h *NotificationsHandler) ListNotifications(c *gin.Context) {
+	params, err := pagination.Parse(c, dto.ListNotificationsResource)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	res, metadata, err := h.NotificationsService.ListNotifications(c.Request.Context(), params)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithMetadata(c, http.StatusOK, res, metadata)
+}
+
+// MarkNotificationRead marks a Notification as read.
+// @Summary Mark a Notification as read.
+// @Description This endpoint marks one of the current user's notifications as read.
+// @Tags notifications
+// @Param id path int true "The Notification ID."
+// @Success 204
+// @Failure 400 {object} response.Base
+// @Failure 401 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/notifications/{id}/read [post]
+func (h *NotificationsHandler) MarkNotificationRead(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	if err := h.NotificationsService.MarkNotificationRead(c.Request.Context(), id); err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// MarkAllNotificationsRead marks every Notification as read.
+// @Summary Mark all Notifications as read.
+// @Description This endpoint marks every unread notification of the current user as read.
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.MarkAllReadResponse}
+// @Failure 401 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/notifications/read [post]
+func (h *NotificationsHandler) MarkAllNotificationsRead(c *gin.Context) {
+	res, err := h.NotificationsService.MarkAllNotificationsRead(c.Request.Context())
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// GetNotificationPreferences resolves the current user's notification preferences.
+// @Summary Get notification preferences.
+// @Description This endpoint returns the current user's email address and locale for notifications, and the channels used for every notification type.
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.PreferencesResponse}
+// @Failure 401 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/notifications/preferences [get]
+func (h *NotificationsHandler) GetNotificationPreferences(c *gin.Context) {
+	res, err := h.NotificationsService.GetNotificationPreferences(c.Request.Context())
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// UpdateNotificationPreferences replaces the current user's notification preferences.
+// @Summary Update notification preferences.
+// @Description This endpoint sets the current user's email address and locale for notifications, and the channels used for the listed notification types. Types left out keep their current channels.
+// @Tags notifications
+// @Param Preferences body dto.UpdatePreferencesRequest true "The new preferences."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.PreferencesResponse}
+// @Failure 400 {object} response.Base
+// @Failure 401 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/notifications/preferences [put]
+func (h *NotificationsHandler) UpdateNotificationPreferences(c *gin.Context) {
+	var req dto.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.NotificationsService.UpdateNotificationPreferences(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
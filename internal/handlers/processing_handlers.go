@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/processing/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// CreateProcessingBatch records a new Processing Batch.
+// @Summary Record a new Processing Batch.
+// @Description This endpoint records a slaughter and processing run. The listed animals are marked as slaughtered and every product gets a lot code.
+// @Tags processing
+// @Param ProcessingBatch body dto.CreateProcessingBatchRequest true "The Processing Batch to be recorded."
+// @Produce json
+// @Success 201 {object} response.Base{data=dto.ProcessingBatchResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 409 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/processing-batches [post]
+func (h *ProcessingHandler) CreateProcessingBatch(c *gin.Context) {
+	var req dto.CreateProcessingBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.ProcessingService.CreateProcessingBatch(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// GetTrace traces a product lot back to its source.
+// @Summary Trace a product lot.
+// @Description This endpoint walks a product lot back to its processing batch, source animals, their treatment history, pens and feed lots.
+// @Tags processing
+// @Param lotCode path string true "The product lot code."
+// @Produce json
+// @Success 200 {object} response.Base{data=model.Trace}
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/trace/{lotCode} [get]
+func (h *ProcessingHandler) GetTrace(c *gin.Context) {
+	res, err := h.ProcessingService.GetTrace(c.Request.Context(), c.Param("lotCode"))
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// CreateSupplier creates a new Supplier.
+// @Summary Create a new Supplier.
+// @Description This endpoint creates a new Supplier.
+// @Tags purchasing
+// @Param Supplier body dto.CreateSupplierRequest true "The Supplier to be created."
+// @Produce json
+// @Success 201 {object} response.Base{data=model.Supplier}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/suppliers [post]
+func (h *PurchasingHandler) CreateSupplier(c *gin.Context) {
+	var req dto.CreateSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.PurchasingService.CreateSupplier(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// ListSuppliers lists all Suppliers.
+// @Summary List Suppliers.
+// @Description This endpoint lists all Suppliers ordered by name.
+// @Tags purchasing
+// @Produce json
+// @Success 200 {object} response.Base{data=[]model.Supplier}
+// @Failure 500 {object} response.Base
+// @Router /v1/suppliers [get]
+func (h *PurchasingHandler) ListSuppliers(c *gin.Context) {
+	res, err := h.PurchasingService.ListSuppliers(c.Request.Context())
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// CreatePurchaseOrder creates a new draft Purchase Order.
+// @Summary Create a new Purchase Order.
+// @Description This endpoint creates a new Purchase Order in draft status.
+// @Tags purchasing
+// @Param PurchaseOrder body dto.CreatePurchaseOrderRequest true "The Purchase Order to be created."
+// @Produce json
+// @Success 201 {object} response.Base{data=dto.PurchaseOrderResponse}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/purchase-orders [post]
+func (h *PurchasingHandler) CreatePurchaseOrder(c *gin.Context) {
+	var req dto.CreatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.PurchasingService.CreatePurchaseOrder(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// GetPurchaseOrder resolves a Purchase Order by its ID.
+// @Summary Get a Purchase Order.
+// @Description This endpoint resolves a Purchase Order and its lines by ID.
+// @Tags purchasing
+// @Param id path int true "The Purchase Order ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.PurchaseOrderResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/purchase-orders/{id} [get]
+func (h *PurchasingHandler) GetPurchaseOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.PurchasingService.GetPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// SendPurchaseOrder marks a draft Purchase Order as sent.
+// @Summary Send a Purchase Order.
+// @Description This endpoint moves a Purchase Order from draft to sent.
+// @Tags purchasing
+// @Param id path int true "The Purchase Order ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.PurchaseOrderResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 409 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/purchase-orders/{id}/send [post]
+func (h *PurchasingHandler) SendPurchaseOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.PurchasingService.SendPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// ReceiveGoods records a Goods Receipt against a Purchase Order.
+// @Summary Receive goods for a Purchase Order.
+// @Description This endpoint records a full or partial delivery, increases stock on hand and moves the Purchase Order to partially_received or received.
+// @Tags purchasing
+// @Param id path int true "The Purchase Order ID."
+// @Param GoodsReceipt body dto.ReceiveGoodsRequest true "The delivered quantities."
+// @Produce json
+// @Success 201 {object} response.Base{data=dto.PurchaseOrderResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 409 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/purchase-orders/{id}/receipts [post]
+func (h *PurchasingHandler) ReceiveGoods(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	var req dto.ReceiveGoodsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.PurchasingService.ReceiveGoods(c.Request.Context(), id, &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// CreateSupplierInvoice records a Supplier Invoice against a Purchase Order.
+// @Summary Record a Supplier Invoice.
+// @Description This endpoint records a Supplier Invoice and matches it against the ordered prices and received quantities.
+// @Tags purchasing
+// @Param id path int true "The Purchase Order ID."
+// @Param SupplierInvoice body dto.CreateSupplierInvoiceRequest true "The Supplier Invoice to be recorded."
+// @Produce json
+// @Success 201 {object} response.Base{data=dto.SupplierInvoiceResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 409 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/purchase-orders/{id}/invoices [post]
+func (h *PurchasingHandler) CreateSupplierInvoice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	var req dto.CreateSupplierInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.PurchasingService.CreateSupplierInvoice(c.Request.Context(), id, &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// ClosePurchaseOrder closes a received Purchase Order.
+// @Summary Close a Purchase Order.
+// @Description This endpoint moves a fully received Purchase Order to closed.
+// @Tags purchasing
+// @Param id path int true "The Purchase Order ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.PurchaseOrderResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 409 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/purchase-orders/{id}/close [post]
+func (h *PurchasingHandler) ClosePurchaseOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.PurchasingService.ClosePurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
 	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
 )
 
@@ -12,23 +17,110 @@ import (
 // @Tags users
 // @Param User body dto.CreateUserRequest true "The User to be created."
 // @Produce json
-// @Success 201 {object} response.Base{data=dto.CreateUserRequest}
+// @Success 201 {object} response.Base{data=dto.UserResponse}
 // @Failure 400 {object} response.Base
 // @Failure 409 {object} response.Base
+// @Failure 429 {object} response.Base
 // @Failure 500 {object} response.Base
 // @Router /v1/users/register [post]
 func (h *UsersHandler) CreateUser(c *gin.Context) {
 	var req dto.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.UserService.CreateUser(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// Login checks a User's credentials and issues an access token.
+// @Summary Log in.
+// @Description This endpoint checks a username and password and returns a bearer token for the other endpoints. Repeated failures lock the account out.
+// @Tags users
+// @Param Credentials body dto.LoginRequest true "The username and password."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.LoginResponse}
+// @Failure 400 {object} response.Base
+// @Failure 401 {object} response.Base
+// @Failure 429 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/users/login [post]
+func (h *UsersHandler) Login(c *gin.Context) {
+	var req dto.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.UserService.Login(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// ListUsers lists Users.
+// @Summary List Users.
+// @Description This endpoint lists Users with offset (page, limit) or cursor (after, limit) pagination.
+// @Tags users
+// @Param page query int false "Page number, starting at 1."
+// @Param limit query int false "Page size, at most 100."
+// @Param after query string false "Cursor from a previous page's nextCursor. Only valid when sorting by id."
+// @Param sort query string false "Comma-separated fields to sort by, prefixed with - for descending: id, username."
+// @Param username query string false "Filter by username. Use username[like] to match partially."
+// @Param roleId query int false "Filter by role ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=[]dto.UserResponse,metadata=pagination.Metadata}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/users [get]
+func (h *UsersHandler) ListUsers(c *gin.Context) {
+	params, err := pagination.Parse(c, dto.ListUsersResource)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	res, metadata, err := h.UserService.ListUsers(c.Request.Context(), params)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithMetadata(c, http.StatusOK, res, metadata)
+}
+
+// GetUser resolves a User by its ID.
+// @Summary Get a User.
+// @Description This endpoint resolves a User by ID.
+// @Tags users
+// @Param id path int true "The User ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.UserResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/users/{id} [get]
+func (h *UsersHandler) GetUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
 		return
 	}
 
-	err := h.UserService.CreateUser(c, &req)
+	res, err := h.UserService.GetUser(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		response.WithError(c, err)
 		return
 	}
 
-	response.WithJSON(c, 201, req)
+	response.WithJSON(c, http.StatusOK, res)
 }
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// CreateWebhook subscribes a URL to events.
+// @Summary Create a new Webhook.
+// @Description This endpoint subscribes a URL to events. Event types are event names, such as mortality.recorded or user.registered, or "*" to receive every event. Each delivery is signed in X-Webhook-Signature as sha256=HMAC-SHA256(secret, timestamp + "." + body), with the timestamp in X-Webhook-Timestamp. The secret is only returned here.
+// @Tags webhooks
+// @Param Webhook body dto.CreateWebhookRequest true "The Webhook to be created."
+// @Produce json
+// @Success 201 {object} response.Base{data=dto.CreateWebhookResponse}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/webhooks [post]
+func (h *WebhooksHandler) CreateWebhook(c *gin.Context) {
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.WebhooksService.CreateWebhook(c.Request.Context(), &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusCreated, res)
+}
+
+// ListWebhooks lists all Webhooks.
+// @Summary List Webhooks.
+// @Description This endpoint lists all Webhook subscriptions.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} response.Base{data=[]dto.WebhookResponse}
+// @Failure 500 {object} response.Base
+// @Router /v1/webhooks [get]
+func (h *WebhooksHandler) ListWebhooks(c *gin.Context) {
+	res, err := h.WebhooksService.ListWebhooks(c.Request.Context())
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// GetWebhook resolves a Webhook by its ID.
+// @Summary Get a Webhook.
+// @Description This endpoint resolves a Webhook subscription by ID.
+// @Tags webhooks
+// @Param id path int true "The Webhook ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.WebhookResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/webhooks/{id} [get]
+func (h *WebhooksHandler) GetWebhook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.WebhooksService.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// UpdateWebhook replaces a Webhook's settings.
+// @Summary Update a Webhook.
+// @Description This endpoint replaces the URL, event types and active flag of a Webhook. A non-empty secret rotates the signing secret.
+// @Tags webhooks
+// @Param id path int true "The Webhook ID."
+// @Param Webhook body dto.UpdateWebhookRequest true "The new Webhook settings."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.WebhookResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/webhooks/{id} [put]
+func (h *WebhooksHandler) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.WebhooksService.UpdateWebhook(c.Request.Context(), id, &req)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// DeleteWebhook removes a Webhook.
+// @Summary Delete a Webhook.
+// @Description This endpoint removes a Webhook subscription together with its delivery log.
+// @Tags webhooks
+// @Param id path int true "The Webhook ID."
+// @Success 204
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/webhooks/{id} [delete]
+func (h *WebhooksHandler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	if err := h.WebhooksService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// ListWebhookDeliveries lists the webhook delivery log.
+// @Summary List Webhook deliveries.
+// @Description This endpoint lists Webhook deliveries, newest first, with offset (page, limit) or cursor (after, limit) pagination.
+// @Tags webhooks
+// @Param webhookId query int false "Filter by Webhook ID."
+// @Param event query string false "Filter by event name."
+// @Param status query string false "Filter by status: pending, succeeded or dead."
+// @Param page query int false "Page number, starting at 1."
+// @Param limit query int false "Page size, at most 100."
+// @Param after query string false "Cursor from a previous page's nextCursor. Only valid when sorting by id."
+// @Param sort query string false "Comma-separated fields to sort by, prefixed with - for descending: id, createdAt."
+// @Produce json
+// @Success 200 {object} response.Base{data=[]dto.DeliveryResponse,metadata=pagination.Metadata}
+// @Failure 400 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/webhook-deliveries [get]
+func (h *WebhooksHandler) ListWebhookDeliveries(c *gin.Context) {
+	params, err := pagination.Parse(c, dto.ListWebhookDeliveriesResource)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	res, metadata, err := h.WebhooksService.ListDeliveries(c.Request.Context(), params)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithMetadata(c, http.StatusOK, res, metadata)
+}
+
+// GetWebhookDelivery resolves a Webhook delivery by its ID.
+// @Summary Get a Webhook delivery.
+// @Description This endpoint resolves a Webhook delivery by ID, with the log of its attempts.
+// @Tags webhooks
+// @Param id path int true "The delivery ID."
+// @Produce json
+// @Success 200 {object} response.Base{data=dto.DeliveryResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/webhook-deliveries/{id} [get]
+func (h *WebhooksHandler) GetWebhookDelivery(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.WebhooksService.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusOK, res)
+}
+
+// ReplayWebhookDelivery sends a Webhook delivery again.
+// @Summary Replay a Webhook delivery.
+// @Description This endpoint queues a Webhook delivery to be sent again with a fresh set of attempts, whatever its status. The body keeps the original event ID.
+// @Tags webhooks
+// @Param id path int true "The delivery ID."
+// @Produce json
+// @Success 202 {object} response.Base{data=dto.DeliveryResponse}
+// @Failure 400 {object} response.Base
+// @Failure 404 {object} response.Base
+// @Failure 500 {object} response.Base
+// @Router /v1/webhook-deliveries/{id}/replay [post]
+func (h *WebhooksHandler) ReplayWebhookDelivery(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.WithError(c, failure.BadRequest(err))
+		return
+	}
+
+	res, err := h.WebhooksService.ReplayDelivery(c.Request.Context(), id)
+	if err != nil {
+		response.WithError(c, err)
+		return
+	}
+
+	response.WithJSON(c, http.StatusAccepted, res)
+}
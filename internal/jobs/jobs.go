@@ -0,0 +1,28 @@
+// Package jobs registers the scheduled jobs of every domain. It is the one
+// place the schedule is assembled, so constructing a service never changes
+// what the scheduler runs.
+package jobs
+
+import (
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	mortalityServices "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/services"
+	notificationsServices "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/services"
+)
+
+// DomainJobs is a struct that contains all services with scheduled jobs.
+type DomainJobs struct {
+	MortalityService     *mortalityServices.MortalityServiceImpl
+	NotificationsService *notificationsServices.NotificationsServiceImpl
+}
+
+// Scheduler is the scheduler with the jobs of every domain registered.
+type Scheduler struct {
+	*infras.Scheduler
+}
+
+// ProvideScheduler registers the jobs of every domain with scheduler.
+func ProvideScheduler(scheduler *infras.Scheduler, domainJobs DomainJobs) *Scheduler {
+	domainJobs.MortalityService.RegisterJobs(scheduler)
+	domainJobs.NotificationsService.RegisterJobs(scheduler)
+	return &Scheduler{Scheduler: scheduler}
+}
@@ -4,6 +4,9 @@ package main
 //go:generate go run github.com/google/wire/cmd/wire
 
 import (
+	"os"
+
+	"github.com/rs/zerolog/log"
 	"github.com/sanika-farm/sanika-farm-be/configs"
 	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
 )
@@ -12,10 +15,32 @@ var configSvc *configs.Config
 
 func main() {
 	logger.InitLogger()
-	configSvc = configs.Get()
+	var err error
+	configSvc, err = configs.Get()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed loading configuration.")
+		os.Exit(1)
+	}
+	if err := logger.Configure(configSvc); err != nil {
+		log.Error().Err(err).Msg("Failed configuring logger.")
+		os.Exit(1)
+	}
 	logger.SetLogLevel(configSvc)
+	logger.WatchLogLevel(configs.Watch(configSvc))
 
 	// InitializeApp()
-	httpSvc := InitializeApp()
-	httpSvc.SetupAndServe()
+	httpSvc, err := InitializeApp()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed initializing application.")
+		os.Exit(1)
+	}
+	// `worker` runs the background workers and scheduled jobs without
+	// serving HTTP.
+	run := httpSvc.SetupAndServe
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		run = httpSvc.RunWorker
+	}
+	if err := run(); err != nil {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS incomes;
+DROP TABLE IF EXISTS expense_allocations;
+DROP TABLE IF EXISTS expenses;
@@ -0,0 +1,32 @@
+CREATE TABLE expenses (
+    id          SERIAL PRIMARY KEY,
+    category    TEXT NOT NULL CHECK (category IN ('feed', 'vet', 'labour', 'utilities', 'capex')),
+    description TEXT NOT NULL DEFAULT '',
+    amount      NUMERIC NOT NULL CHECK (amount > 0),
+    incurred_on DATE NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX expenses_incurred_on_idx ON expenses (incurred_on);
+
+CREATE TABLE expense_allocations (
+    id         SERIAL PRIMARY KEY,
+    expense_id INTEGER NOT NULL REFERENCES expenses (id) ON DELETE CASCADE,
+    enterprise TEXT NOT NULL CHECK (enterprise IN ('dairy', 'poultry', 'crops')),
+    pen_id     INTEGER,
+    flock_id   INTEGER,
+    amount     NUMERIC NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX expense_allocations_expense_id_idx ON expense_allocations (expense_id);
+
+CREATE TABLE incomes (
+    id          SERIAL PRIMARY KEY,
+    enterprise  TEXT NOT NULL CHECK (enterprise IN ('dairy', 'poultry', 'crops')),
+    description TEXT NOT NULL DEFAULT '',
+    amount      NUMERIC NOT NULL CHECK (amount > 0),
+    received_on DATE NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX incomes_received_on_idx ON incomes (received_on);
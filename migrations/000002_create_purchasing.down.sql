@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS supplier_invoice_lines;
+DROP TABLE IF EXISTS supplier_invoices;
+DROP TABLE IF EXISTS goods_receipt_lines;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS stock_items;
+DROP TABLE IF EXISTS suppliers;
@@ -0,0 +1,84 @@
+CREATE TABLE suppliers (
+    id           SERIAL PRIMARY KEY,
+    name         TEXT NOT NULL,
+    contact_name TEXT NOT NULL DEFAULT '',
+    phone        TEXT NOT NULL DEFAULT '',
+    email        TEXT NOT NULL DEFAULT '',
+    address      TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE stock_items (
+    id               SERIAL PRIMARY KEY,
+    name             TEXT NOT NULL,
+    category         TEXT NOT NULL CHECK (category IN ('feed', 'medicine', 'supplies')),
+    unit             TEXT NOT NULL,
+    quantity_on_hand NUMERIC NOT NULL DEFAULT 0,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE purchase_orders (
+    id          SERIAL PRIMARY KEY,
+    supplier_id INTEGER NOT NULL REFERENCES suppliers (id),
+    status      TEXT NOT NULL CHECK (status IN ('draft', 'sent', 'partially_received', 'received', 'closed')),
+    ordered_on  DATE NOT NULL,
+    expected_on DATE,
+    notes       TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX purchase_orders_supplier_id_idx ON purchase_orders (supplier_id);
+
+CREATE TABLE purchase_order_lines (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    stock_item_id     INTEGER NOT NULL REFERENCES stock_items (id),
+    description       TEXT NOT NULL DEFAULT '',
+    quantity_ordered  NUMERIC NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received NUMERIC NOT NULL DEFAULT 0 CHECK (quantity_received >= 0),
+    unit_price        NUMERIC NOT NULL CHECK (unit_price >= 0)
+);
+
+CREATE INDEX purchase_order_lines_purchase_order_id_idx ON purchase_order_lines (purchase_order_id);
+
+CREATE TABLE goods_receipts (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders (id),
+    received_on       DATE NOT NULL,
+    notes             TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX goods_receipts_purchase_order_id_idx ON goods_receipts (purchase_order_id);
+
+CREATE TABLE goods_receipt_lines (
+    id                     SERIAL PRIMARY KEY,
+    goods_receipt_id       INTEGER NOT NULL REFERENCES goods_receipts (id) ON DELETE CASCADE,
+    purchase_order_line_id INTEGER NOT NULL REFERENCES purchase_order_lines (id),
+    quantity               NUMERIC NOT NULL CHECK (quantity > 0)
+);
+
+CREATE TABLE supplier_invoices (
+    id                SERIAL PRIMARY KEY,
+    supplier_id       INTEGER NOT NULL REFERENCES suppliers (id),
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders (id),
+    invoice_number    TEXT NOT NULL,
+    invoice_date      DATE NOT NULL,
+    amount            NUMERIC NOT NULL,
+    match_status      TEXT NOT NULL CHECK (match_status IN ('matched', 'discrepancy')),
+    match_notes       TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX supplier_invoices_purchase_order_id_idx ON supplier_invoices (purchase_order_id);
+
+CREATE TABLE supplier_invoice_lines (
+    id                     SERIAL PRIMARY KEY,
+    supplier_invoice_id    INTEGER NOT NULL REFERENCES supplier_invoices (id) ON DELETE CASCADE,
+    purchase_order_line_id INTEGER NOT NULL REFERENCES purchase_order_lines (id),
+    quantity               NUMERIC NOT NULL,
+    unit_price             NUMERIC NOT NULL
+);
+
+CREATE INDEX supplier_invoice_lines_supplier_invoice_id_idx ON supplier_invoice_lines (supplier_invoice_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS mortality_records;
+DROP TABLE IF EXISTS animals;
+DROP TABLE IF EXISTS pens;
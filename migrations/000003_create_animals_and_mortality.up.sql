@@ -0,0 +1,32 @@
+CREATE TABLE pens (
+    id   SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE animals (
+    id         SERIAL PRIMARY KEY,
+    tag_number TEXT NOT NULL UNIQUE,
+    species    TEXT NOT NULL,
+    birth_date DATE,
+    pen_id     INTEGER REFERENCES pens (id),
+    status     TEXT NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX animals_pen_id_idx ON animals (pen_id);
+
+CREATE TABLE mortality_records (
+    id               SERIAL PRIMARY KEY,
+    animal_id        INTEGER NOT NULL UNIQUE REFERENCES animals (id),
+    type             TEXT NOT NULL CHECK (type IN ('death', 'cull')),
+    occurred_on      DATE NOT NULL,
+    cause_category   TEXT NOT NULL CHECK (cause_category IN ('disease', 'injury', 'predation', 'birth_complication',
+                                                             'poisoning', 'old_age', 'low_productivity', 'unknown')),
+    note             TEXT NOT NULL DEFAULT '',
+    disposal_method  TEXT NOT NULL CHECK (disposal_method IN ('burial', 'incineration', 'rendering', 'composting', 'slaughter')),
+    found_by_user_id INTEGER REFERENCES users (id),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX mortality_records_occurred_on_idx ON mortality_records (occurred_on);
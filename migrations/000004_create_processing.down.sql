@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS processing_batch_animals;
+DROP TABLE IF EXISTS processing_batches;
+DROP TABLE IF EXISTS feedings;
+DROP TABLE IF EXISTS feed_lots;
+DROP TABLE IF EXISTS treatments;
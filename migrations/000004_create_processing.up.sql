@@ -0,0 +1,52 @@
+CREATE TABLE treatments (
+    id                 SERIAL PRIMARY KEY,
+    animal_id          INTEGER NOT NULL REFERENCES animals (id),
+    product            TEXT NOT NULL,
+    dose               TEXT NOT NULL DEFAULT '',
+    administered_on    DATE NOT NULL,
+    withdrawal_ends_on DATE
+);
+
+CREATE INDEX treatments_animal_id_idx ON treatments (animal_id);
+
+CREATE TABLE feed_lots (
+    id         SERIAL PRIMARY KEY,
+    lot_number TEXT NOT NULL,
+    feed_name  TEXT NOT NULL,
+    supplier   TEXT
+);
+
+CREATE TABLE feedings (
+    id          SERIAL PRIMARY KEY,
+    pen_id      INTEGER NOT NULL REFERENCES pens (id),
+    feed_lot_id INTEGER NOT NULL REFERENCES feed_lots (id),
+    fed_on      DATE NOT NULL,
+    quantity_kg NUMERIC NOT NULL CHECK (quantity_kg > 0)
+);
+
+CREATE INDEX feedings_pen_id_fed_on_idx ON feedings (pen_id, fed_on);
+
+CREATE TABLE processing_batches (
+    id           SERIAL PRIMARY KEY,
+    processed_on DATE NOT NULL,
+    notes        TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE processing_batch_animals (
+    batch_id  INTEGER NOT NULL REFERENCES processing_batches (id) ON DELETE CASCADE,
+    animal_id INTEGER NOT NULL UNIQUE REFERENCES animals (id),
+    PRIMARY KEY (batch_id, animal_id)
+);
+
+CREATE TABLE products (
+    id          SERIAL PRIMARY KEY,
+    batch_id    INTEGER NOT NULL REFERENCES processing_batches (id) ON DELETE CASCADE,
+    lot_code    TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL,
+    weight_kg   NUMERIC NOT NULL CHECK (weight_kg > 0),
+    packaged_on DATE NOT NULL,
+    expires_on  DATE
+);
+
+CREATE INDEX products_batch_id_idx ON products (batch_id);
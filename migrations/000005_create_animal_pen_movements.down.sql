@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS animals_pen_movement_update ON animals;
+DROP TRIGGER IF EXISTS animals_pen_movement_insert ON animals;
+DROP FUNCTION IF EXISTS record_animal_pen_movement();
+DROP TABLE IF EXISTS animal_pen_movements;
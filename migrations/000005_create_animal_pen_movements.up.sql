@@ -0,0 +1,45 @@
+-- Every stay of an animal in a pen. The open stay, without moved_out_on, is
+-- the animal's current pen. The trigger keeps the history in step with
+-- animals.pen_id, so writers only have to update the animal.
+CREATE TABLE animal_pen_movements (
+    id           SERIAL PRIMARY KEY,
+    animal_id    INTEGER NOT NULL REFERENCES animals (id),
+    pen_id       INTEGER NOT NULL REFERENCES pens (id),
+    moved_in_on  DATE NOT NULL,
+    moved_out_on DATE,
+    CHECK (moved_out_on IS NULL OR moved_out_on >= moved_in_on)
+);
+
+CREATE INDEX animal_pen_movements_animal_id_idx ON animal_pen_movements (animal_id, moved_in_on);
+CREATE UNIQUE INDEX animal_pen_movements_open_idx ON animal_pen_movements (animal_id) WHERE moved_out_on IS NULL;
+
+CREATE FUNCTION record_animal_pen_movement() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        UPDATE animal_pen_movements
+        SET moved_out_on = CURRENT_DATE
+        WHERE animal_id = NEW.id AND moved_out_on IS NULL;
+    END IF;
+    IF NEW.pen_id IS NOT NULL THEN
+        INSERT INTO animal_pen_movements (animal_id, pen_id, moved_in_on)
+        VALUES (NEW.id, NEW.pen_id, CASE WHEN TG_OP = 'INSERT' THEN COALESCE(NEW.birth_date, CURRENT_DATE) ELSE CURRENT_DATE END);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER animals_pen_movement_insert
+    AFTER INSERT ON animals
+    FOR EACH ROW EXECUTE FUNCTION record_animal_pen_movement();
+
+CREATE TRIGGER animals_pen_movement_update
+    AFTER UPDATE OF pen_id ON animals
+    FOR EACH ROW WHEN (OLD.pen_id IS DISTINCT FROM NEW.pen_id)
+    EXECUTE FUNCTION record_animal_pen_movement();
+
+-- Animals recorded before the history existed are taken to have lived in
+-- their current pen since birth.
+INSERT INTO animal_pen_movements (animal_id, pen_id, moved_in_on)
+SELECT id, pen_id, COALESCE(birth_date, created_at::date)
+FROM animals
+WHERE pen_id IS NOT NULL;
@@ -0,0 +1,15 @@
+CREATE TABLE audit_log (
+    id            BIGSERIAL PRIMARY KEY,
+    actor_user_id INTEGER REFERENCES users (id),
+    action        TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    entity_type   TEXT NOT NULL,
+    entity_id     TEXT NOT NULL,
+    before        JSONB,
+    after         JSONB,
+    request_id    TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_log_entity_idx ON audit_log (entity_type, entity_id);
+CREATE INDEX audit_log_actor_user_id_idx ON audit_log (actor_user_id);
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
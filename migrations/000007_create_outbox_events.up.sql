@@ -0,0 +1,16 @@
+CREATE TABLE outbox_events (
+    id            BIGSERIAL PRIMARY KEY,
+    name          TEXT NOT NULL,
+    payload       JSONB NOT NULL,
+    request_id    TEXT,
+    occurred_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    available_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    attempts      INTEGER NOT NULL DEFAULT 0,
+    last_error    TEXT,
+    dispatched_at TIMESTAMPTZ,
+    failed_at     TIMESTAMPTZ
+);
+
+-- The relay only ever scans events still waiting to be dispatched.
+CREATE INDEX outbox_events_pending_idx ON outbox_events (available_at, id)
+    WHERE dispatched_at IS NULL AND failed_at IS NULL;
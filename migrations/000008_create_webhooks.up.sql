@@ -0,0 +1,43 @@
+CREATE TABLE webhook_subscriptions (
+    id          SERIAL PRIMARY KEY,
+    url         TEXT NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    secret      TEXT NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Deleting a subscription deletes its delivery log with it.
+CREATE TABLE webhook_deliveries (
+    id               BIGSERIAL PRIMARY KEY,
+    subscription_id  INTEGER NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id         BIGINT NOT NULL,
+    event_name       TEXT NOT NULL,
+    payload          JSONB NOT NULL,
+    occurred_at      TIMESTAMPTZ NOT NULL,
+    status           TEXT NOT NULL CHECK (status IN ('pending', 'succeeded', 'dead')),
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INTEGER,
+    last_error       TEXT,
+    delivered_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (subscription_id, event_id)
+);
+
+-- The dispatcher only ever scans deliveries still waiting to be sent.
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at, id)
+    WHERE status = 'pending';
+
+CREATE TABLE webhook_delivery_attempts (
+    id           BIGSERIAL PRIMARY KEY,
+    delivery_id  BIGINT NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    attempt      INTEGER NOT NULL,
+    status_code  INTEGER,
+    error        TEXT,
+    duration_ms  BIGINT NOT NULL,
+    attempted_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX webhook_delivery_attempts_delivery_id_idx ON webhook_delivery_attempts (delivery_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS report_snapshots;
+DROP TABLE IF EXISTS job_runs;
@@ -0,0 +1,24 @@
+-- The unique (job_name, scheduled_at) key lets only one replica run each
+-- occurrence of a job.
+CREATE TABLE job_runs (
+    id           BIGSERIAL PRIMARY KEY,
+    job_name     TEXT NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    started_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at  TIMESTAMPTZ,
+    status       TEXT NOT NULL CHECK (status IN ('running', 'succeeded', 'failed')),
+    duration_ms  BIGINT,
+    error        TEXT,
+    instance     TEXT NOT NULL,
+    UNIQUE (job_name, scheduled_at)
+);
+
+CREATE TABLE report_snapshots (
+    id           BIGSERIAL PRIMARY KEY,
+    report       TEXT NOT NULL,
+    period_start DATE NOT NULL,
+    period_end   DATE NOT NULL,
+    data         JSONB NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (report, period_start, period_end)
+);
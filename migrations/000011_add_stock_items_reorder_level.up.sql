@@ -0,0 +1,3 @@
+-- Feed items below their reorder level raise a low feed notification. Items
+-- without a reorder level are never reported.
+ALTER TABLE stock_items ADD COLUMN reorder_level NUMERIC CHECK (reorder_level >= 0);
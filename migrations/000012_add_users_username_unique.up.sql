@@ -0,0 +1,3 @@
+-- Registering a taken username fails with a conflict, and logins look users
+-- up by username.
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_key ON users (username);
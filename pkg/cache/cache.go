@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	keyPrefix   = "cache:"
+	tagPrefix   = "cache-tag:"
+	lockPrefix  = "cache-lock:"
+	lockTTL     = 5 * time.Second
+	lockPollGap = 50 * time.Millisecond
+)
+
+// releaseLock deletes a lock only while it still holds the caller's token, so
+// a load that outlived its lock cannot release the lock of the next holder.
+var releaseLock = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Options control how a loaded value is cached.
+type Options struct {
+	// TTL is how long the value stays cached.
+	TTL time.Duration
+	// Tags group cached values so they can be invalidated together.
+	Tags []string
+}
+
+// Cache is a cache-aside helper. Concurrent misses for the same key are
+// collapsed into one load per process, and a short-lived lock in Redis makes
+// other replicas wait for that load instead of hitting the database. Redis
+// failures never fail a read; the value is loaded directly instead.
+type Cache struct {
+	client redis.UniversalClient
+
+	mu       sync.Mutex
+	inflight map[string]*call
+}
+
+type call struct {
+	wg    sync.WaitGroup
+	value []byte
+	err   error
+}
+
+// New creates a Cache on top of client. A nil client disables caching, and
+// every read loads the value directly.
+func New(client redis.UniversalClient) *Cache {
+	return &Cache{
+		client:   client,
+		inflight: make(map[string]*call),
+	}
+}
+
+func (c *Cache) enabled() bool {
+	return c != nil && c.client != nil
+}
+
+// GetOrLoad returns the cached value of key, or loads it, caches it and
+// returns it on a miss.
+func GetOrLoad[T any](ctx context.Context, c *Cache, key string, opts Options, load func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	if !c.enabled() {
+		return load(ctx)
+	}
+
+	raw, err := c.do(ctx, keyPrefix+key, opts, func(ctx context.Context) ([]byte, error) {
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(v)
+	})
+	if err != nil {
+		return value, err
+	}
+
+	err = json.Unmarshal(raw, &value)
+	return value, err
+}
+
+// Invalidate drops every value cached with any of the tags.
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) error {
+	if !c.enabled() {
+		return nil
+	}
+	for _, tag := range tags {
+		keys, err := c.client.SMembers(ctx, tagPrefix+tag).Result()
+		if err != nil {
+			return err
+		}
+		if err = c.client.Del(ctx, append(keys, tagPrefix+tag)...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete drops the cached values of keys.
+func (c *Cache) Delete(ctx context.Context, keys ...string) error {
+	if !c.enabled() || len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, keyPrefix+key)
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
+
+func (c *Cache) do(ctx context.Context, key string, opts Options, load func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if raw, ok := c.get(ctx, key); ok {
+		return raw, nil
+	}
+
+	c.mu.Lock()
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		cl.wg.Wait()
+		return cl.value, cl.err
+	}
+	cl := &call{}
+	cl.wg.Add(1)
+	c.inflight[key] = cl
+	c.mu.Unlock()
+
+	cl.value, cl.err = c.loadLocked(ctx, key, opts, load)
+	cl.wg.Done()
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.mu.Unlock()
+
+	return cl.value, cl.err
+}
+
+// loadLocked loads the value while holding the Redis lock of key. If another
+// replica holds the lock, it waits for that replica to fill the cache and
+// only loads the value itself when the lock expires.
+func (c *Cache) loadLocked(ctx context.Context, key string, opts Options, load func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	lockKey := lockPrefix + key
+	token, err := newLockToken()
+	if err != nil {
+		return load(ctx)
+	}
+	acquired, err := c.client.SetNX(ctx, lockKey, token, lockTTL).Result()
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Cache lock unavailable, loading directly.")
+		return load(ctx)
+	}
+
+	if !acquired {
+		deadline := time.Now().Add(lockTTL)
+		for time.Now().Before(deadline) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(lockPollGap):
+			}
+			if raw, ok := c.get(ctx, key); ok {
+				return raw, nil
+			}
+		}
+		return load(ctx)
+	}
+	defer func() {
+		if err := releaseLock.Run(ctx, c.client, []string{lockKey}, token).Err(); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed releasing cache lock.")
+		}
+	}()
+
+	raw, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.set(ctx, key, raw, opts)
+	return raw, nil
+}
+
+func (c *Cache) get(ctx context.Context, key string) ([]byte, bool) {
+	raw, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Warn().Err(err).Str("key", key).Msg("Cache read failed.")
+		}
+		return nil, false
+	}
+	return raw, true
+}
+
+// set stores the value and adds it to its tag sets in one transaction, so a
+// value is never cached without being reachable from its tags.
+func (c *Cache) set(ctx context.Context, key string, raw []byte, opts Options) {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, raw, opts.TTL)
+		for _, tag := range opts.Tags {
+			pipe.SAdd(ctx, tagPrefix+tag, key)
+			if opts.TTL > 0 {
+				// Keep the tag set alive as long as the key just added.
+				pipe.Expire(ctx, tagPrefix+tag, opts.TTL)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Cache write failed.")
+	}
+}
+
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,218 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestCache(t *testing.T) (*Cache, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return New(client), mr
+}
+
+func counter(calls *atomic.Int32, value string) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		calls.Add(1)
+		return value, nil
+	}
+}
+
+func TestGetOrLoadCachesValue(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	var calls atomic.Int32
+
+	for i := 0; i < 3; i++ {
+		got, err := GetOrLoad(ctx, c, "k", Options{TTL: time.Minute}, counter(&calls, "v"))
+		if err != nil {
+			t.Fatalf("GetOrLoad: %v", err)
+		}
+		if got != "v" {
+			t.Fatalf("GetOrLoad = %q, want %q", got, "v")
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loaded %d times, want 1", n)
+	}
+	if raw, err := mr.Get(keyPrefix + "k"); err != nil || raw != `"v"` {
+		t.Errorf("stored %q (%v), want %q", raw, err, `"v"`)
+	}
+	if mr.Exists(lockPrefix + keyPrefix + "k") {
+		t.Error("lock was not released after the load")
+	}
+}
+
+func TestGetOrLoadDoesNotCacheErrors(t *testing.T) {
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+	wantErr := errors.New("boom")
+
+	_, err := GetOrLoad(ctx, c, "k", Options{TTL: time.Minute}, func(context.Context) (string, error) {
+		return "", wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GetOrLoad error = %v, want %v", err, wantErr)
+	}
+
+	var calls atomic.Int32
+	if _, err := GetOrLoad(ctx, c, "k", Options{TTL: time.Minute}, counter(&calls, "v")); err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loaded %d times after a failed load, want 1", n)
+	}
+}
+
+func TestGetOrLoadExpires(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	var calls atomic.Int32
+	opts := Options{TTL: time.Minute, Tags: []string{"t"}}
+
+	if _, err := GetOrLoad(ctx, c, "k", opts, counter(&calls, "v")); err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if ttl := mr.TTL(keyPrefix + "k"); ttl != time.Minute {
+		t.Errorf("value TTL = %v, want %v", ttl, time.Minute)
+	}
+	if ttl := mr.TTL(tagPrefix + "t"); ttl != time.Minute {
+		t.Errorf("tag TTL = %v, want %v", ttl, time.Minute)
+	}
+
+	mr.FastForward(time.Minute + time.Second)
+	if _, err := GetOrLoad(ctx, c, "k", opts, counter(&calls, "v")); err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("loaded %d times, want 2 after expiry", n)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	var calls atomic.Int32
+
+	if _, err := GetOrLoad(ctx, c, "a", Options{TTL: time.Minute, Tags: []string{"users"}}, counter(&calls, "a")); err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if _, err := GetOrLoad(ctx, c, "b", Options{TTL: time.Minute, Tags: []string{"users"}}, counter(&calls, "b")); err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if _, err := GetOrLoad(ctx, c, "c", Options{TTL: time.Minute, Tags: []string{"other"}}, counter(&calls, "c")); err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+
+	if err := c.Invalidate(ctx, "users"); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+	for _, key := range []string{keyPrefix + "a", keyPrefix + "b", tagPrefix + "users"} {
+		if mr.Exists(key) {
+			t.Errorf("%s survived invalidation", key)
+		}
+	}
+	if !mr.Exists(keyPrefix + "c") {
+		t.Error("value with another tag was invalidated")
+	}
+}
+
+func TestLockReleaseKeepsAnotherHoldersLock(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	lockKey := lockPrefix + keyPrefix + "k"
+
+	_, err := GetOrLoad(ctx, c, "k", Options{TTL: time.Minute}, func(context.Context) (string, error) {
+		// The lock expired during a slow load and another replica took it.
+		mr.Del(lockKey)
+		if err := mr.Set(lockKey, "other"); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		return "v", nil
+	})
+	if err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+
+	if got, err := mr.Get(lockKey); err != nil || got != "other" {
+		t.Errorf("lock = %q (%v), want the other holder's token", got, err)
+	}
+}
+
+func TestLockedKeyWaitsForHolder(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	lockKey := lockPrefix + keyPrefix + "k"
+	if err := mr.Set(lockKey, "other"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// The holder fills the cache shortly after.
+	go func() {
+		time.Sleep(2 * lockPollGap)
+		mr.Set(keyPrefix+"k", `"theirs"`)
+	}()
+
+	var calls atomic.Int32
+	got, err := GetOrLoad(ctx, c, "k", Options{TTL: time.Minute}, counter(&calls, "mine"))
+	if err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if got != "theirs" || calls.Load() != 0 {
+		t.Errorf("GetOrLoad = %q with %d loads, want the holder's value and no load", got, calls.Load())
+	}
+}
+
+func TestConcurrentMissesLoadOnce(t *testing.T) {
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := GetOrLoad(ctx, c, "k", Options{TTL: time.Minute}, func(context.Context) (string, error) {
+				calls.Add(1)
+				<-release
+				return "v", nil
+			})
+			if err != nil || got != "v" {
+				t.Errorf("GetOrLoad = %q, %v", got, err)
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loaded %d times, want 1", n)
+	}
+}
+
+func TestDisabledCacheLoadsDirectly(t *testing.T) {
+	var calls atomic.Int32
+	for i := 0; i < 2; i++ {
+		if _, err := GetOrLoad(context.Background(), New(nil), "k", Options{TTL: time.Minute}, counter(&calls, "v")); err != nil {
+			t.Fatalf("GetOrLoad: %v", err)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("loaded %d times, want 2", n)
+	}
+	if err := New(nil).Invalidate(context.Background(), "t"); err != nil {
+		t.Errorf("Invalidate: %v", err)
+	}
+}
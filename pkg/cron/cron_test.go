@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+	// Monday, October 19 2026.
+	monday := utc(2026, 10, 19, 10, 17)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"step", "*/15 * * * *", monday, utc(2026, 10, 19, 10, 30)},
+		{"list", "5,35 * * * *", monday, utc(2026, 10, 19, 10, 35)},
+		{"range with step", "0 9-17/4 * * *", monday, utc(2026, 10, 19, 13, 0)},
+		{"value with step", "0 20/2 * * *", monday, utc(2026, 10, 19, 20, 0)},
+		{"strictly after", "17 10 * * *", monday, utc(2026, 10, 20, 10, 17)},
+		{"next day", "0 9 * * *", monday, utc(2026, 10, 20, 9, 0)},
+		{"month names", "0 0 1 JAN,JUL *", monday, utc(2027, 1, 1, 0, 0)},
+		{"year rollover", "0 0 1 * *", utc(2026, 12, 31, 23, 59), utc(2027, 1, 1, 0, 0)},
+		{"skips short months", "0 0 31 * *", utc(2026, 11, 1, 0, 0), utc(2026, 12, 31, 0, 0)},
+		{"leap day", "0 0 29 2 *", monday, utc(2028, 2, 29, 0, 0)},
+		{"never matches", "0 0 30 2 *", monday, time.Time{}},
+
+		{"day of week range", "0 8 * * MON-FRI", utc(2026, 10, 23, 9, 0), utc(2026, 10, 26, 8, 0)},
+		{"seven is Sunday", "0 0 * * 7", monday, utc(2026, 10, 25, 0, 0)},
+		{"day of month or day of week", "0 0 13 * FRI", monday, utc(2026, 10, 23, 0, 0)},
+		{"day of week or day of month", "0 0 1 * MON", utc(2026, 10, 27, 0, 0), utc(2026, 11, 1, 0, 0)},
+		{"day of month step is unrestricted", "0 0 */2 * MON", monday, utc(2026, 11, 9, 0, 0)},
+		{"day of week step is unrestricted", "0 0 13 * */2", monday, utc(2026, 12, 13, 0, 0)},
+
+		{"@yearly", "@yearly", monday, utc(2027, 1, 1, 0, 0)},
+		{"@monthly", "@monthly", monday, utc(2026, 11, 1, 0, 0)},
+		{"@weekly", "@weekly", monday, utc(2026, 10, 25, 0, 0)},
+		{"@daily", "@daily", monday, utc(2026, 10, 20, 0, 0)},
+		{"@hourly", "@hourly", monday, utc(2026, 10, 19, 11, 0)},
+		{"@every aligns to the interval", "@every 10m", monday, utc(2026, 10, 19, 10, 20)},
+		{"@every hour", "@every 1h", monday, utc(2026, 10, 19, 11, 0)},
+
+		// Clocks go forward from 02:00 to 03:00 on March 8 and back from
+		// 02:00 to 01:00 on November 1 2026.
+		{"hourly over spring forward", "0 * * * *",
+			time.Date(2026, 3, 8, 1, 30, 0, 0, newYork), time.Date(2026, 3, 8, 3, 0, 0, 0, newYork)},
+		{"daily over spring forward", "0 9 * * *",
+			time.Date(2026, 3, 7, 10, 0, 0, 0, newYork), time.Date(2026, 3, 8, 9, 0, 0, 0, newYork)},
+		{"skipped time", "30 2 * * *",
+			time.Date(2026, 3, 7, 3, 0, 0, 0, newYork), time.Date(2026, 3, 9, 2, 30, 0, 0, newYork)},
+		{"hourly over fall back", "0 * * * *",
+			time.Date(2026, 11, 1, 1, 30, 0, 0, newYork), utc(2026, 11, 1, 6, 0)},
+		{"repeated time", "30 1 * * *",
+			time.Date(2026, 11, 1, 1, 30, 0, 0, newYork), utc(2026, 11, 1, 6, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.spec, err)
+			}
+			got := s.Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != tt.from.Location() {
+				t.Errorf("Next(%v) is in %v, want %v", tt.from, got.Location(), tt.from.Location())
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * MON-FOO",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"1/x * * * *",
+		"@every soon",
+		"@every 500ms",
+		"@fortnightly",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", spec)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package errreport
+
+import "context"
+
+// Reporter sends errors to an error-tracking service. Implementations must
+// be safe for concurrent use and should not block the caller for long.
+type Reporter interface {
+	Report(ctx context.Context, err error, stack []byte)
+}
+
+// Nop is a Reporter that discards every error. It is the default until a
+// real sink is configured.
+type Nop struct{}
+
+// Report does nothing.
+func (Nop) Report(context.Context, error, []byte) {}
@@ -0,0 +1,97 @@
+// Package events is an in-process bus for domain events. Events are
+// published through the transactional outbox and dispatched to subscribers
+// by the outbox relay, so a subscriber may see the same event more than once
+// and must be idempotent, for example by remembering Message.ID.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Event is a domain event. EventName identifies the kind of event, such as
+// "user.registered", and routes it to its subscribers.
+type Event interface {
+	EventName() string
+}
+
+// Message is an event as delivered to subscribers, with its JSON payload.
+type Message struct {
+	ID         int64
+	Name       string
+	Payload    json.RawMessage
+	OccurredAt time.Time
+	// Attempt is 1 on the first delivery and grows with every retry.
+	Attempt int
+}
+
+// Handler handles a delivered event. Returning an error has the event
+// delivered again later.
+type Handler func(ctx context.Context, msg Message) error
+
+type subscription struct {
+	subscriber string
+	handle     Handler
+}
+
+// Bus routes events to their subscribers. It is safe for concurrent use.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]subscription
+	all      []subscription
+}
+
+// NewBus returns a bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]subscription)}
+}
+
+// Subscribe registers handle for events named name. subscriber names the
+// subscriber in logs and errors.
+func (b *Bus) Subscribe(name, subscriber string, handle Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], subscription{subscriber: subscriber, handle: handle})
+}
+
+// SubscribeAll registers handle for every event, whatever its name.
+func (b *Bus) SubscribeAll(subscriber string, handle Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, subscription{subscriber: subscriber, handle: handle})
+}
+
+// Subscribe registers handle for events of type E, decoding each payload
+// into E before calling it.
+func Subscribe[E Event](b *Bus, subscriber string, handle func(ctx context.Context, event E) error) {
+	var zero E
+	b.Subscribe(zero.EventName(), subscriber, func(ctx context.Context, msg Message) error {
+		var event E
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return fmt.Errorf("decoding %s: %w", msg.Name, err)
+		}
+		return handle(ctx, event)
+	})
+}
+
+// Dispatch delivers msg to every subscriber of its name and to those
+// subscribed to all events. Every subscriber is called even if an earlier
+// one fails, and the failures are joined. Events without subscribers are
+// dropped.
+func (b *Bus) Dispatch(ctx context.Context, msg Message) error {
+	b.mu.RLock()
+	subs := append(append([]subscription(nil), b.handlers[msg.Name]...), b.all...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if err := sub.handle(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.subscriber, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,139 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds how long a single check may take.
+const DefaultTimeout = 2 * time.Second
+
+// Check statuses.
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+)
+
+// CheckFunc reports whether a dependency is usable. It should honour the
+// context deadline.
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of a single check. A failed optional check is
+// reported without making the server unready.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+	Optional  bool    `json:"optional,omitempty"`
+}
+
+// Report is the outcome of all registered checks.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Healthy reports whether every required check passed.
+func (r Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// Registry holds the readiness checks of the server. Infrastructure registers
+// its checks when it is provided.
+type Registry struct {
+	mu      sync.RWMutex
+	checks  map[string]registeredCheck
+	Timeout time.Duration
+}
+
+type registeredCheck struct {
+	fn       CheckFunc
+	optional bool
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		checks:  make(map[string]registeredCheck),
+		Timeout: DefaultTimeout,
+	}
+}
+
+// Register adds a named check, replacing any check with the same name.
+func (r *Registry) Register(name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = registeredCheck{fn: fn}
+}
+
+// RegisterOptional adds a named check of a dependency the server can work
+// without. Its failures are reported but do not make the server unready.
+func (r *Registry) RegisterOptional(name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = registeredCheck{fn: fn, optional: true}
+}
+
+// Names returns the registered check names in order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs all checks concurrently, each bounded by the registry timeout.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make(map[string]registeredCheck, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks))}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check registeredCheck) {
+			defer wg.Done()
+			result := r.run(ctx, check.fn)
+			result.Optional = check.optional
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusUp && !check.optional {
+				report.Status = StatusDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, check CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
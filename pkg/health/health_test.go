@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRunIgnoresFailedOptionalChecks(t *testing.T) {
+	up := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name     string
+		required CheckFunc
+		optional CheckFunc
+		want     string
+	}{
+		{"all up", up, up, StatusUp},
+		{"optional down", up, down, StatusUp},
+		{"required down", down, up, StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.Register("postgres.write", tt.required)
+			registry.RegisterOptional("postgres.read", tt.optional)
+
+			report := registry.Run(context.Background())
+			if report.Status != tt.want {
+				t.Errorf("status = %s, want %s", report.Status, tt.want)
+			}
+			if read := report.Checks["postgres.read"]; !read.Optional {
+				t.Errorf("postgres.read = %+v, want it reported as optional", read)
+			}
+			if write := report.Checks["postgres.write"]; write.Optional {
+				t.Errorf("postgres.write = %+v, want it reported as required", write)
+			}
+		})
+	}
+
+	registry := NewRegistry()
+	registry.RegisterOptional("postgres.read", down)
+	if read := registry.Run(context.Background()).Checks["postgres.read"]; read.Status != StatusDown || read.Error == "" {
+		t.Errorf("failed optional check = %+v, want it reported down with its error", read)
+	}
+}
@@ -1,35 +1,132 @@
 package logger
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/sanika-farm/sanika-farm-be/configs"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// InitLogger initializes the logger
+// InitLogger initializes the logger with console output on stdout, until
+// Configure applies the configured output.
 func InitLogger() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	zerolog.SetGlobalLevel(zerolog.TraceLevel)
 
 	output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
-	// format the output as needed here
-
-	log.Logger = log.Output(output)
+	log.Logger = log.Output(redactWriter{out: output}).Hook(traceHook{})
 	log.Trace().Msg("Zerolog initialized.")
 }
 
-// ErrorWithStack logs and error and its stack trace with custom formatting.
-func ErrorWithStack(err error) {
-	log.Error().Msgf("%+v", errors.WithStack(err))
+// Configure applies the log format, destination and sampling configured in
+// config.Server.Log. Sensitive fields are always redacted.
+func Configure(config *configs.Config) error {
+	logConfig := config.Server.Log
+
+	var destination io.Writer
+	switch strings.ToLower(logConfig.Output) {
+	case "", "stdout":
+		destination = os.Stdout
+	case "file":
+		file, err := OpenRotatingFile(logConfig.File.Path, logConfig.File.MaxSizeMB, logConfig.File.MaxBackups, logConfig.File.MaxAgeDays)
+		if err != nil {
+			return err
+		}
+		destination = file
+	default:
+		return fmt.Errorf("unknown log output %q, expected stdout or file", logConfig.Output)
+	}
+
+	var output io.Writer
+	switch strings.ToLower(logConfig.Format) {
+	case "", "console":
+		output = zerolog.ConsoleWriter{Out: destination, TimeFormat: time.RFC3339, NoColor: destination != os.Stdout}
+	case "json":
+		zerolog.TimeFieldFormat = time.RFC3339Nano
+		output = destination
+	default:
+		return fmt.Errorf("unknown log format %q, expected console or json", logConfig.Format)
+	}
+
+	logger := zerolog.New(redactWriter{out: output}).With().Timestamp().Logger().Hook(traceHook{})
+	if sampler := levelSampler(logConfig.Sampling.TraceEvery, logConfig.Sampling.DebugEvery, logConfig.Sampling.InfoEvery); sampler != nil {
+		logger = logger.Sample(sampler)
+	}
+	log.Logger = logger
+
+	log.Trace().
+		Str("format", logConfig.Format).
+		Str("output", logConfig.Output).
+		Msg("Logger configured.")
+	return nil
+}
+
+// levelSampler keeps one in every n events of the noisy levels. Warnings and
+// errors are never sampled. It returns nil when nothing is sampled.
+func levelSampler(traceEvery, debugEvery, infoEvery uint32) zerolog.Sampler {
+	every := func(n uint32) zerolog.Sampler {
+		if n <= 1 {
+			return nil
+		}
+		return &zerolog.BasicSampler{N: n}
+	}
+	sampler := zerolog.LevelSampler{
+		TraceSampler: every(traceEvery),
+		DebugSampler: every(debugEvery),
+		InfoSampler:  every(infoEvery),
+	}
+	if sampler.TraceSampler == nil && sampler.DebugSampler == nil && sampler.InfoSampler == nil {
+		return nil
+	}
+	return sampler
+}
+
+// traceHook adds the trace and span IDs to events logged with a context that
+// carries a span, e.g. log.Info().Ctx(ctx).
+type traceHook struct{}
+
+func (traceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	ctx := e.GetCtx()
+	if ctx == nil {
+		return
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	e.Str("trace_id", sc.TraceID().String()).Str("span_id", sc.SpanID().String())
+}
+
+// ErrorWithStack logs and error and its stack trace with custom formatting,
+// through the request-scoped logger in ctx when there is one.
+func ErrorWithStack(ctx context.Context, err error) {
+	FromContext(ctx).Error().Msgf("%+v", errors.WithStack(err))
 }
 
 // SetLogLevel sets the desired log level specified in env var.
 func SetLogLevel(config *configs.Config) {
-	level, err := zerolog.ParseLevel(config.Server.LogLevel)
+	setLevel(config.Server.LogLevel)
+}
+
+// WatchLogLevel applies changes of the log level published by watcher.
+func WatchLogLevel(watcher *configs.Watcher) {
+	watcher.OnLogLevelChanged(func(change configs.LogLevelChanged) {
+		setLevel(change.Level)
+		log.Info().Str("loglevel", zerolog.GlobalLevel().String()).Msg("Log level changed.")
+	})
+}
+
+// setLevel sets the global level, which zerolog stores atomically.
+func setLevel(desired string) {
+	level, err := zerolog.ParseLevel(desired)
 	if err != nil {
 		level = zerolog.TraceLevel
 		log.Trace().Str("loglevel", level.String()).Msg("Environment has no log level set up, using default.")
@@ -0,0 +1,194 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are matched against lowercased field names, as substrings,
+// so that e.g. "newPassword" and "X-Api-Key" are caught too.
+var sensitiveKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"authorization",
+	"cookie",
+	"api_key",
+	"apikey",
+	"api-key",
+	"private_key",
+}
+
+// credentialPattern finds credentials embedded in free text, such as an
+// Authorization header value quoted in an error message.
+var credentialPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+
+// redactWriter masks sensitive fields in each JSON log event before passing
+// it on. It sits between zerolog and the real output, so credentials are
+// masked whichever logger, hook or field wrote them. Events are rewritten
+// token by token, so fields keep their order and numbers their form.
+type redactWriter struct {
+	out io.Writer
+}
+
+func (w redactWriter) Write(p []byte) (int, error) {
+	if !mayContainSecret(p) {
+		return w.out.Write(p)
+	}
+
+	out, err := redactJSON(p)
+	if err != nil {
+		// Not a JSON event; write it masked as plain text.
+		out = credentialPattern.ReplaceAll(p, []byte("$1 "+redacted))
+	}
+	if _, err := w.out.Write(out); err != nil {
+		return 0, err
+	}
+	// Report the original length, as zerolog expects the whole event written.
+	return len(p), nil
+}
+
+// jsonFrame is an object or array being rewritten.
+type jsonFrame struct {
+	object bool
+	// n is the number of values written so far.
+	n int
+	// expectKey tells that the next string is a key.
+	expectKey bool
+}
+
+// redactJSON rewrites a JSON document, replacing the values of sensitive keys
+// and credentials embedded in strings.
+func redactJSON(p []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+	var stack []*jsonFrame
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var top *jsonFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			buf.WriteByte(byte(delim))
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		if top != nil && top.object && top.expectKey {
+			key := tok.(string)
+			if top.n > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(&buf, key)
+			buf.WriteByte(':')
+			top.expectKey = false
+			if isSensitiveKey(key) {
+				if err := skipJSONValue(decoder); err != nil {
+					return nil, err
+				}
+				writeJSONString(&buf, redacted)
+				top.n++
+				top.expectKey = true
+			}
+			continue
+		}
+
+		if top != nil {
+			if !top.object && top.n > 0 {
+				buf.WriteByte(',')
+			}
+			top.n++
+			top.expectKey = top.object
+		}
+
+		switch tok := tok.(type) {
+		case json.Delim:
+			buf.WriteByte(byte(tok))
+			stack = append(stack, &jsonFrame{object: tok == '{', expectKey: tok == '{'})
+		case string:
+			writeJSONString(&buf, credentialPattern.ReplaceAllString(tok, "$1 "+redacted))
+		case json.Number:
+			buf.WriteString(tok.String())
+		case bool:
+			buf.WriteString(strconv.FormatBool(tok))
+		case nil:
+			buf.WriteString("null")
+		}
+	}
+	if len(stack) > 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if bytes.HasSuffix(p, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// skipJSONValue reads the next value, however deeply nested, without writing it.
+func skipJSONValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			if delim == '{' || delim == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// writeJSONString writes s as a JSON string, leaving <, > and & as they are,
+// like zerolog does.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	// Encode ends the value with a newline.
+	buf.Truncate(buf.Len() - 1)
+}
+
+func mayContainSecret(p []byte) bool {
+	lower := bytes.ToLower(p)
+	for _, key := range sensitiveKeys {
+		if bytes.Contains(lower, []byte(key)) {
+			return true
+		}
+	}
+	return bytes.Contains(lower, []byte("bearer")) || bytes.Contains(lower, []byte("basic"))
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRedactWriterKeepsEventShape(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "sensitive field",
+			in:   `{"level":"info","password":"hunter2","user":"ann","time":1700000000}` + "\n",
+			want: `{"level":"info","password":"[REDACTED]","user":"ann","time":1700000000}` + "\n",
+		},
+		{
+			name: "nested and structured values",
+			in:   `{"z":1.50,"headers":{"Authorization":["Bearer abc"],"Accept":"*/*"},"tokens":{"a":[1,{"b":2}]},"after":true}` + "\n",
+			want: `{"z":1.50,"headers":{"Authorization":"[REDACTED]","Accept":"*/*"},"tokens":"[REDACTED]","after":true}` + "\n",
+		},
+		{
+			name: "credential in text",
+			in:   `{"message":"call failed: Bearer abc.def <html>","list":[null,"basic dXNlcg=="]}` + "\n",
+			want: `{"message":"call failed: Bearer [REDACTED] <html>","list":[null,"basic [REDACTED]"]}` + "\n",
+		},
+		{
+			name: "plain text",
+			in:   "sent Authorization: Bearer abc\n",
+			want: "sent Authorization: Bearer [REDACTED]\n",
+		},
+		{
+			name: "nothing sensitive",
+			in:   `{"b":2,"a":1}` + "\n",
+			want: `{"b":2,"a":1}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			n, err := redactWriter{out: &out}.Write([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if n != len(tt.in) {
+				t.Errorf("Write = %d, want %d", n, len(tt.in))
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
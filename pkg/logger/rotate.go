@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFile is a log file that is rotated once it grows past MaxSizeMB.
+// Rotated files are renamed with a timestamp suffix, and only the newest
+// MaxBackups younger than MaxAgeDays are kept. Zero disables each limit.
+type RotatingFile struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// OpenRotatingFile opens, or creates, the log file at path.
+func OpenRotatingFile(path string, maxSizeMB, maxBackups, maxAgeDays int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		Path:       path,
+		MaxSizeMB:  maxSizeMB,
+		MaxBackups: maxBackups,
+		MaxAgeDays: maxAgeDays,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o755); err != nil {
+		return fmt.Errorf("creating log directory: %w", err)
+	}
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("reading log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write writes p to the file, rotating it first if p would push it past its
+// maximum size.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	maxSize := int64(f.MaxSizeMB) * 1024 * 1024
+	if maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	backup := f.Path + "." + time.Now().UTC().Format(backupTimeFormat)
+	if err := os.Rename(f.Path, backup); err != nil {
+		return fmt.Errorf("rotating log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.prune()
+	return nil
+}
+
+// prune removes the backups beyond MaxBackups or older than MaxAgeDays.
+// Failing to remove a backup must not stop logging, so errors are ignored.
+func (f *RotatingFile) prune() {
+	backups, err := filepath.Glob(f.Path + ".*")
+	if err != nil {
+		return
+	}
+	// The timestamp suffix sorts chronologically; newest first.
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -f.MaxAgeDays)
+	for i, backup := range backups {
+		tooMany := f.MaxBackups > 0 && i >= f.MaxBackups
+		tooOld := false
+		if f.MaxAgeDays > 0 {
+			rotatedAt, err := time.Parse(backupTimeFormat, strings.TrimPrefix(backup, f.Path+"."))
+			tooOld = err == nil && rotatedAt.Before(cutoff)
+		}
+		if tooMany || tooOld {
+			_ = os.Remove(backup)
+		}
+	}
+}
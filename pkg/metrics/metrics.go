@@ -0,0 +1,149 @@
+// Package metrics registers the service's metrics with a Prometheus registry
+// and serves them with promhttp. Metrics are created by name with their label
+// names, and label values are passed positionally when they are updated.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultBuckets are latency buckets in seconds, suited to HTTP requests.
+var DefaultBuckets = prometheus.DefBuckets
+
+// Sample is a single labelled value reported by a collect function.
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// Registry holds the metrics of the service, along with the Go runtime and
+// process metrics.
+type Registry struct {
+	registry *prometheus.Registry
+}
+
+// NewRegistry creates a Registry holding the Go runtime and process
+// collectors.
+func NewRegistry() *Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return &Registry{registry: registry}
+}
+
+// Registerer returns the underlying registerer, for collectors that are not
+// created through the Registry.
+func (r *Registry) Registerer() prometheus.Registerer {
+	return r.registry
+}
+
+// NewCounter registers a counter with the given label names.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	r.registry.MustRegister(vec)
+	return &Counter{vec: vec}
+}
+
+// NewGauge registers a gauge with the given label names.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+	r.registry.MustRegister(vec)
+	return &Gauge{vec: vec}
+}
+
+// NewHistogram registers a histogram with the given buckets and label names.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+	r.registry.MustRegister(vec)
+	return &Histogram{vec: vec}
+}
+
+// NewGaugeFunc registers a gauge whose samples are read by collect at scrape time.
+func (r *Registry) NewGaugeFunc(name, help string, labels []string, collect func() []Sample) {
+	r.registry.MustRegister(newFuncCollector(name, help, prometheus.GaugeValue, labels, collect))
+}
+
+// NewCounterFunc registers a counter whose samples are read by collect at scrape time.
+func (r *Registry) NewCounterFunc(name, help string, labels []string, collect func() []Sample) {
+	r.registry.MustRegister(newFuncCollector(name, help, prometheus.CounterValue, labels, collect))
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// Counter is a monotonically increasing value per label set.
+type Counter struct {
+	vec *prometheus.CounterVec
+}
+
+// Inc adds one to the counter with the given label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Inc()
+}
+
+// Add adds v, which must not be negative, to the counter with the given label values.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	c.vec.WithLabelValues(labelValues...).Add(v)
+}
+
+// Gauge is a value per label set that can go up and down.
+type Gauge struct {
+	vec *prometheus.GaugeVec
+}
+
+// Set sets the gauge with the given label values to v.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).Set(v)
+}
+
+// Add adds v to the gauge with the given label values.
+func (g *Gauge) Add(v float64, labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).Add(v)
+}
+
+// Histogram counts observations in cumulative buckets per label set.
+type Histogram struct {
+	vec *prometheus.HistogramVec
+}
+
+// Observe records v for the given label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Observe(v)
+}
+
+// funcCollector reports the samples of a collect function as constant
+// metrics on every scrape.
+type funcCollector struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	collect   func() []Sample
+}
+
+func newFuncCollector(name, help string, valueType prometheus.ValueType, labels []string, collect func() []Sample) *funcCollector {
+	return &funcCollector{
+		desc:      prometheus.NewDesc(name, help, labels, nil),
+		valueType: valueType,
+		collect:   collect,
+	}
+}
+
+func (f *funcCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- f.desc
+}
+
+func (f *funcCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range f.collect() {
+		ch <- prometheus.MustNewConstMetric(f.desc, f.valueType, s.Value, s.LabelValues...)
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(body)
+}
+
+func TestHandlerExposesMetrics(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounter("test_requests_total", "Requests.", "route")
+	latency := r.NewHistogram("test_latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	r.NewGaugeFunc("test_pool_open", "Open connections.", []string{"pool"}, func() []Sample {
+		return []Sample{{LabelValues: []string{"writer"}, Value: 3}}
+	})
+
+	requests.Inc("/users")
+	requests.Add(2, "/users")
+	latency.Observe(0.5, "/users")
+
+	body := scrape(t, r)
+	for _, want := range []string{
+		`test_requests_total{route="/users"} 3`,
+		`test_latency_seconds_bucket{route="/users",le="0.1"} 0`,
+		`test_latency_seconds_bucket{route="/users",le="1"} 1`,
+		`test_pool_open{pool="writer"} 3`,
+		"go_goroutines",
+		"process_start_time_seconds",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape is missing %q", want)
+		}
+	}
+}
+
+func TestRegisteringTwicePanics(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("test_total", "Test.")
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a metric twice did not panic")
+		}
+	}()
+	r.NewCounter("test_total", "Test.")
+}
@@ -0,0 +1,92 @@
+// Package netguard keeps requests to URLs supplied by clients, such as
+// webhooks, away from the service's own network: loopback, private,
+// link-local (including cloud metadata endpoints) and other addresses that
+// are not publicly routable.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// resolveTimeout bounds the lookup of a host name in CheckHost.
+const resolveTimeout = 5 * time.Second
+
+// ErrForbiddenAddress is returned for addresses that are not publicly
+// routable.
+var ErrForbiddenAddress = errors.New("address is not publicly routable")
+
+// blocked are the special-purpose ranges not covered by the netip methods
+// used in Allowed.
+var blocked = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// lookup resolves host names. Tests replace it.
+var lookup = net.DefaultResolver.LookupNetIP
+
+// Allowed reports whether ip is a publicly routable unicast address.
+func Allowed(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blocked {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckHost resolves host, a name or an IP address, and fails unless every
+// address it resolves to is allowed.
+func CheckHost(ctx context.Context, host string) error {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !Allowed(ip) {
+			return fmt.Errorf("%s: %w", host, ErrForbiddenAddress)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+	ips, err := lookup(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", host, err)
+	}
+	for _, ip := range ips {
+		if !Allowed(ip) {
+			return fmt.Errorf("%s resolves to %s: %w", host, ip.Unmap(), ErrForbiddenAddress)
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control function refusing to connect to addresses
+// that are not allowed. It runs after name resolution, so a host that passed
+// CheckHost cannot be pointed at an internal address later.
+func Control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !Allowed(ip) {
+		return fmt.Errorf("dialing %s: %w", address, ErrForbiddenAddress)
+	}
+	return nil
+}
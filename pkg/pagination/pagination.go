@@ -0,0 +1,406 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+const (
+	// DefaultLimit is the page size used when a resource does not set one.
+	DefaultLimit = 20
+	// MaxLimit is the largest page size used when a resource does not set one.
+	MaxLimit = 100
+)
+
+// Operators supported in filters, written as `?field[op]=value`. A bare
+// `?field=value` is an equality filter, and like matches case-insensitively
+// anywhere in the value.
+const (
+	OpEqual          = "eq"
+	OpNotEqual       = "ne"
+	OpGreater        = "gt"
+	OpGreaterOrEqual = "gte"
+	OpLess           = "lt"
+	OpLessOrEqual    = "lte"
+	OpLike           = "like"
+)
+
+var operators = map[string]string{
+	OpEqual:          "=",
+	OpNotEqual:       "<>",
+	OpGreater:        ">",
+	OpGreaterOrEqual: ">=",
+	OpLess:           "<",
+	OpLessOrEqual:    "<=",
+	OpLike:           "ILIKE",
+}
+
+// FieldType is the type of a filterable field. Filter values are parsed as
+// their field's type, so a malformed value is a bad request rather than a
+// database error.
+type FieldType int
+
+const (
+	String FieldType = iota
+	Int
+	Bool
+	// Time accepts RFC 3339 timestamps and dates such as 2024-01-31.
+	Time
+)
+
+// Resource is the allow-list of fields a list endpoint can be sorted and
+// filtered by, mapped to the SQL columns behind them.
+type Resource struct {
+	// Columns maps public field names to SQL columns.
+	Columns map[string]string
+	// Sortable lists the fields allowed in ?sort=.
+	Sortable []string
+	// Filterable maps the fields allowed as filters to their types.
+	Filterable map[string]FieldType
+	// DefaultSort is used when no ?sort= is given, e.g. "-id".
+	DefaultSort string
+	// CursorField is the unique, sortable field used for ?after= cursors.
+	// Cursor pagination is disabled when it is empty.
+	CursorField string
+	// CursorType is the type of CursorField.
+	CursorType   FieldType
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Sort is a single sort key.
+type Sort struct {
+	Field  string
+	Column string
+	Desc   bool
+}
+
+// Filter is a single filter condition. Value is already parsed as the
+// field's type.
+type Filter struct {
+	Field    string
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// Params are the validated list parameters of a request.
+type Params struct {
+	Page    int
+	Limit   int
+	After   interface{}
+	Sorts   []Sort
+	Filters []Filter
+
+	cursorField  string
+	cursorColumn string
+}
+
+// Metadata is the standard metadata of list responses.
+type Metadata struct {
+	Total      *int   `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Parse reads ?page=&limit=, ?after=, ?sort= and filters from the request and
+// validates them against the resource. Invalid parameters yield a bad request
+// failure.
+func Parse(c *gin.Context, res Resource) (Params, error) {
+	defaultLimit, maxLimit := res.DefaultLimit, res.MaxLimit
+	if defaultLimit <= 0 {
+		defaultLimit = DefaultLimit
+	}
+	if maxLimit <= 0 {
+		maxLimit = MaxLimit
+	}
+
+	p := Params{
+		Page:         1,
+		Limit:        defaultLimit,
+		cursorField:  res.CursorField,
+		cursorColumn: res.Columns[res.CursorField],
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return p, failure.BadRequestFromString(fmt.Sprintf("limit must be between 1 and %d", maxLimit))
+		}
+		p.Limit = limit
+	}
+
+	if v := c.Query("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return p, failure.BadRequestFromString("page must be a positive number")
+		}
+		p.Page = page
+	}
+
+	sorts, err := parseSort(c.DefaultQuery("sort", res.DefaultSort), res)
+	if err != nil {
+		return p, err
+	}
+	p.Sorts = sorts
+
+	if v := c.Query("after"); v != "" {
+		if res.CursorField == "" {
+			return p, failure.BadRequestFromString("cursor pagination is not supported")
+		}
+		if c.Query("page") != "" {
+			return p, failure.BadRequestFromString("page and after cannot be combined")
+		}
+		if !p.sortedByCursor() {
+			return p, failure.BadRequestFromString(fmt.Sprintf("after can only be used when sorting by %s", res.CursorField))
+		}
+		after, err := DecodeCursor(v)
+		if err != nil {
+			return p, err
+		}
+		if p.After, err = parseValue(after, res.CursorType); err != nil {
+			return p, failure.BadRequestFromString("invalid cursor")
+		}
+	}
+
+	filters, err := parseFilters(c, res)
+	if err != nil {
+		return p, err
+	}
+	p.Filters = filters
+
+	return p, nil
+}
+
+func parseSort(sort string, res Resource) ([]Sort, error) {
+	var sorts []Sort
+	if sort == "" {
+		return sorts, nil
+	}
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		if !contains(res.Sortable, field) {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("cannot sort by %q", field))
+		}
+		sorts = append(sorts, Sort{Field: field, Column: res.Columns[field], Desc: desc})
+	}
+	return sorts, nil
+}
+
+func parseFilters(c *gin.Context, res Resource) ([]Filter, error) {
+	query := c.Request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var filters []Filter
+	for _, key := range keys {
+		values := query[key]
+		field, op := key, OpEqual
+		if i := strings.Index(key, "["); i > 0 && strings.HasSuffix(key, "]") {
+			field, op = key[:i], key[i+1:len(key)-1]
+		}
+		typ, ok := res.Filterable[field]
+		if !ok {
+			continue
+		}
+		if _, ok := operators[op]; !ok {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("unknown filter operator %q on %s", op, field))
+		}
+		if op == OpLike && typ != String {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("%s cannot be matched with like", field))
+		}
+		for _, raw := range values {
+			value, err := parseValue(raw, typ)
+			if err != nil {
+				return nil, failure.BadRequestFromString(fmt.Sprintf("invalid value %q for %s: %v", raw, field, err))
+			}
+			filters = append(filters, Filter{Field: field, Column: res.Columns[field], Operator: op, Value: value})
+		}
+	}
+	return filters, nil
+}
+
+// parseValue parses a filter or cursor value as typ.
+func parseValue(value string, typ FieldType) (interface{}, error) {
+	switch typ {
+	case Int:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a whole number")
+		}
+		return n, nil
+	case Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("must be true or false")
+		}
+		return b, nil
+	case Time:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, fmt.Errorf("must be a date or an RFC 3339 timestamp")
+		}
+		return t, nil
+	}
+	return value, nil
+}
+
+// IsCursor reports whether the request asked for cursor pagination.
+func (p Params) IsCursor() bool {
+	return p.After != nil
+}
+
+// CacheKey returns a string identifying the page the parameters select, so
+// list results can be cached per page. Values are query-escaped, so a filter
+// value cannot pass for another parameter.
+func (p Params) CacheKey() string {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(p.Page))
+	values.Set("limit", strconv.Itoa(p.Limit))
+	if p.After != nil {
+		values.Set("after", formatValue(p.After))
+	}
+	for _, s := range p.Sorts {
+		values.Add("sort", s.Field+":"+strconv.FormatBool(s.Desc))
+	}
+	for _, f := range p.Filters {
+		values.Add(f.Field+"["+f.Operator+"]", formatValue(f.Value))
+	}
+	return values.Encode()
+}
+
+func formatValue(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+// Where returns the WHERE clause for the filters and cursor, with
+// placeholders numbered from $1, and its arguments. It returns an empty
+// string when there is nothing to filter on.
+func (p Params) Where() (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	for _, f := range p.Filters {
+		if f.Operator == OpLike {
+			args = append(args, fmt.Sprintf("%%%v%%", f.Value))
+		} else {
+			args = append(args, f.Value)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s $%d", f.Column, operators[f.Operator], len(args)))
+	}
+	if p.IsCursor() {
+		op := ">"
+		if p.Sorts[0].Desc {
+			op = "<"
+		}
+		args = append(args, p.After)
+		conditions = append(conditions, fmt.Sprintf("%s %s $%d", p.cursorColumn, op, len(args)))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// CountWhere is like Where but leaves out the cursor, for counting the total.
+func (p Params) CountWhere() (string, []interface{}) {
+	p.After = nil
+	return p.Where()
+}
+
+// OrderBy returns the ORDER BY clause, or an empty string when unsorted.
+func (p Params) OrderBy() string {
+	if len(p.Sorts) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(p.Sorts))
+	for _, s := range p.Sorts {
+		if s.Desc {
+			keys = append(keys, s.Column+" DESC")
+		} else {
+			keys = append(keys, s.Column+" ASC")
+		}
+	}
+	return " ORDER BY " + strings.Join(keys, ", ")
+}
+
+// LimitOffset returns the LIMIT and OFFSET clause, with placeholders numbered after
+// the argCount arguments already in the query. Cursor pages fetch one extra
+// row so that Page can tell whether there is a next page.
+func (p Params) LimitOffset(argCount int) (string, []interface{}) {
+	if p.IsCursor() {
+		return fmt.Sprintf(" LIMIT $%d", argCount+1), []interface{}{p.Limit + 1}
+	}
+	return fmt.Sprintf(" LIMIT $%d OFFSET $%d", argCount+1, argCount+2), []interface{}{p.Limit, (p.Page - 1) * p.Limit}
+}
+
+// Page trims rows fetched with LimitOffset to the page size and builds the
+// response metadata. Offset pages report the total, and also the cursor of
+// the next page when sorted by the cursor field alone, so clients can switch
+// to ?after=. Cursor pages only report the next cursor. cursor returns the
+// cursor field value of a row.
+func Page[T any](p Params, rows []T, total int, cursor func(T) string) ([]T, Metadata) {
+	meta := Metadata{Limit: p.Limit}
+	if p.IsCursor() {
+		if len(rows) > p.Limit {
+			rows = rows[:p.Limit]
+			meta.NextCursor = EncodeCursor(cursor(rows[len(rows)-1]))
+		}
+		return rows, meta
+	}
+
+	meta.Page = p.Page
+	meta.Total = &total
+	if p.sortedByCursor() && len(rows) == p.Limit && p.Page*p.Limit < total {
+		meta.NextCursor = EncodeCursor(cursor(rows[len(rows)-1]))
+	}
+	return rows, meta
+}
+
+func (p Params) sortedByCursor() bool {
+	return p.cursorField != "" && len(p.Sorts) == 1 && p.Sorts[0].Field == p.cursorField
+}
+
+// EncodeCursor turns a cursor field value into an opaque cursor.
+func EncodeCursor(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// DecodeCursor reverses EncodeCursor.
+func DecodeCursor(cursor string) (string, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(value) == 0 {
+		return "", failure.BadRequestFromString("invalid cursor")
+	}
+	return string(value), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
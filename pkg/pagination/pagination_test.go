@@ -0,0 +1,110 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+)
+
+var testResource = Resource{
+	Columns: map[string]string{
+		"id":        "id",
+		"name":      "name",
+		"roleId":    "role_id",
+		"active":    "active",
+		"createdAt": "created_at",
+	},
+	Sortable: []string{"id", "name"},
+	Filterable: map[string]FieldType{
+		"name":      String,
+		"roleId":    Int,
+		"active":    Bool,
+		"createdAt": Time,
+	},
+	DefaultSort: "id",
+	CursorField: "id",
+	CursorType:  Int,
+}
+
+func parse(t *testing.T, query string) (Params, error) {
+	t.Helper()
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return Parse(c, testResource)
+}
+
+func TestParseFilterTypes(t *testing.T) {
+	p, err := parse(t, "roleId=3&active=true&createdAt[gte]=2024-01-31&name[like]=an")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	where, args := p.Where()
+	if want := " WHERE active = $1 AND created_at >= $2 AND name ILIKE $3 AND role_id = $4"; where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	want := []interface{}{true, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), "%an%", int64(3)}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %#v, want %#v", args, want)
+	}
+}
+
+func TestParseRejectsMalformedValues(t *testing.T) {
+	for _, query := range []string{
+		"roleId=abc",
+		"active=maybe",
+		"createdAt=yesterday",
+		"roleId[like]=1",
+		"after=" + EncodeCursor("abc"),
+	} {
+		_, err := parse(t, query)
+		if failure.GetCode(err) != http.StatusBadRequest {
+			t.Errorf("%s: err = %v, want a bad request", query, err)
+		}
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	p, err := parse(t, "after="+EncodeCursor("42"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	where, args := p.Where()
+	if where != " WHERE id > $1" || !reflect.DeepEqual(args, []interface{}{int64(42)}) {
+		t.Errorf("where = %q, args = %v", where, args)
+	}
+	if where, _ := p.CountWhere(); where != "" {
+		t.Errorf("count where = %q, want none", where)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	p, err := parse(t, "name=budi&sort=-name,id&limit=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.CacheKey(), "limit=10&name%5Beq%5D=budi&page=1&sort=name%3Atrue&sort=id%3Afalse"; got != want {
+		t.Errorf("CacheKey = %q, want %q", got, want)
+	}
+
+	// A value that looks like more parameters must not collide with the
+	// parameters themselves.
+	spoofed, err := parse(t, "name="+url.QueryEscape("budi&roleId[eq]=1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered, err := parse(t, "name=budi&roleId=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spoofed.CacheKey() == filtered.CacheKey() {
+		t.Errorf("CacheKey of a name containing & equals that of two filters: %q", spoofed.CacheKey())
+	}
+}
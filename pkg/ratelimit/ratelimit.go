@@ -0,0 +1,70 @@
+// Package ratelimit implements token-bucket rate limits and progressive
+// lockout after repeated failures, kept in memory or in Redis.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket holding up to Burst requests and refilled with
+// PerMinute requests a minute.
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+// interval is the time it takes to refill one token. A PerMinute below one
+// refills one token a minute rather than dividing by zero.
+func (l Limit) interval() time.Duration {
+	if l.PerMinute < 1 {
+		return time.Minute
+	}
+	return time.Minute / time.Duration(l.PerMinute)
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left.
+	Remaining int
+	// RetryAfter is how long to wait for the next token when not allowed.
+	RetryAfter time.Duration
+}
+
+// Lockout locks a key out after MaxFailures consecutive failures. The first
+// lockout lasts Duration, and every further failure locks the key again for
+// twice as long, up to MaxDuration. Failures are forgotten after a success,
+// or MaxDuration after the last failure.
+type Lockout struct {
+	MaxFailures int
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+// lockFor returns how long a key is locked out after failures consecutive
+// failures, or zero if it is not.
+func (l Lockout) lockFor(failures int) time.Duration {
+	if failures < l.MaxFailures {
+		return 0
+	}
+	lock := float64(l.Duration) * math.Pow(2, float64(failures-l.MaxFailures))
+	if lock > float64(l.MaxDuration) {
+		return l.MaxDuration
+	}
+	return time.Duration(lock)
+}
+
+// Store keeps token buckets and failure counts by key.
+type Store interface {
+	// Allow takes a token from the bucket of key.
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	// LockedFor returns how long key stays locked out, or zero.
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// Fail counts a failure for key and returns how long it is now locked
+	// out, or zero.
+	Fail(ctx context.Context, key string, lockout Lockout) (time.Duration, error)
+	// Reset forgets the failures of key.
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestZeroPerMinuteRefillsOncePerMinute(t *testing.T) {
+	for _, perMinute := range []int{0, -5} {
+		if got := (Limit{PerMinute: perMinute}).interval(); got != time.Minute {
+			t.Errorf("interval with PerMinute %d = %v, want %v", perMinute, got, time.Minute)
+		}
+	}
+
+	s := NewMemoryStore()
+	now := time.Unix(1_700_000_000, 0)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+	limit := Limit{PerMinute: 0, Burst: 1}
+
+	if res, err := s.Allow(ctx, "k", limit); err != nil || !res.Allowed {
+		t.Fatalf("first Allow = %+v, %v", res, err)
+	}
+	res, err := s.Allow(ctx, "k", limit)
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	if res.Allowed || res.RetryAfter != time.Minute {
+		t.Fatalf("Allow = %+v, want a rejection retrying after a minute", res)
+	}
+	now = now.Add(time.Minute)
+	if res, err = s.Allow(ctx, "k", limit); err != nil || !res.Allowed {
+		t.Fatalf("Allow after a minute = %+v, %v", res, err)
+	}
+}
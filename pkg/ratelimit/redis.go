@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit:"
+
+// allowScript refills and takes from a token bucket stored as a hash of
+// tokens and the time of the last refill, in one round trip. It returns
+// whether the request is allowed, the tokens left and the milliseconds until
+// the next token.
+var allowScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(bucket[1]) or burst
+local last = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - last) / interval)
+local allowed, wait = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * interval)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) * interval) + 1000)
+return {allowed, math.floor(tokens), wait}
+`)
+
+// failScript counts a failure and, once there are enough, locks the key out
+// for the progressive lockout duration. It returns the lockout in
+// milliseconds, or zero.
+var failScript = redis.NewScript(`
+local maxFailures = tonumber(ARGV[1])
+local duration = tonumber(ARGV[2])
+local maxDuration = tonumber(ARGV[3])
+local count = redis.call('INCR', KEYS[1])
+if count < maxFailures then
+	redis.call('PEXPIRE', KEYS[1], maxDuration)
+	return 0
+end
+local lock = math.min(maxDuration, duration * 2 ^ (count - maxFailures))
+redis.call('PEXPIRE', KEYS[1], math.max(maxDuration, lock))
+redis.call('SET', KEYS[2], '1', 'PX', lock)
+return lock
+`)
+
+// RedisStore keeps buckets and failures in Redis, so limits are shared by
+// every replica. Keys expire on their own once they no longer matter.
+type RedisStore struct {
+	client redis.UniversalClient
+	now    func() time.Time
+}
+
+// NewRedisStore returns a store backed by client.
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client, now: time.Now}
+}
+
+func (s *RedisStore) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	values, err := allowScript.Run(ctx, s.client, []string{keyPrefix + "bucket:" + key},
+		float64(limit.interval())/float64(time.Millisecond),
+		limit.Burst,
+		s.now().UnixMilli(),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(values) != 3 {
+		return Result{}, fmt.Errorf("ratelimit: unexpected reply %v", values)
+	}
+	return Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+func (s *RedisStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	// PTTL is negative when the key does not exist or has no expiry.
+	ttl, err := s.client.PTTL(ctx, keyPrefix+"lock:"+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	return max(0, ttl), nil
+}
+
+func (s *RedisStore) Fail(ctx context.Context, key string, lockout Lockout) (time.Duration, error) {
+	lock, err := failScript.Run(ctx, s.client, []string{keyPrefix + "failures:" + key, keyPrefix + "lock:" + key},
+		lockout.MaxFailures,
+		lockout.Duration.Milliseconds(),
+		lockout.MaxDuration.Milliseconds(),
+	).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	return time.Duration(lock) * time.Millisecond, nil
+}
+
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	return s.client.Del(ctx, keyPrefix+"failures:"+key).Err()
+}
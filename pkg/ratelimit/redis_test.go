@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisStore(client), mr
+}
+
+func TestRedisStoreAllow(t *testing.T) {
+	s, _ := newTestRedisStore(t)
+	now := time.Unix(1_700_000_000, 0)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+	limit := Limit{PerMinute: 60, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		res, err := s.Allow(ctx, "k", limit)
+		if err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+		if !res.Allowed {
+			t.Fatalf("request %d rejected within the burst", i)
+		}
+	}
+
+	res, err := s.Allow(ctx, "k", limit)
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	if res.Allowed || res.RetryAfter <= 0 || res.RetryAfter > time.Second {
+		t.Fatalf("Allow = %+v, want a rejection retrying within a second", res)
+	}
+
+	now = now.Add(time.Second)
+	if res, err = s.Allow(ctx, "k", limit); err != nil || !res.Allowed {
+		t.Fatalf("Allow after refill = %+v, %v", res, err)
+	}
+}
+
+func TestRedisStoreLockout(t *testing.T) {
+	s, mr := newTestRedisStore(t)
+	ctx := context.Background()
+	lockout := Lockout{MaxFailures: 2, Duration: time.Minute, MaxDuration: time.Hour}
+
+	if locked, err := s.Fail(ctx, "k", lockout); err != nil || locked != 0 {
+		t.Fatalf("first Fail = %v, %v, want no lock", locked, err)
+	}
+	locked, err := s.Fail(ctx, "k", lockout)
+	if err != nil || locked != time.Minute {
+		t.Fatalf("second Fail = %v, %v, want %v", locked, err, time.Minute)
+	}
+	if locked, err = s.LockedFor(ctx, "k"); err != nil || locked <= 0 {
+		t.Fatalf("LockedFor = %v, %v, want a lock", locked, err)
+	}
+
+	mr.FastForward(time.Minute + time.Second)
+	if locked, err = s.LockedFor(ctx, "k"); err != nil || locked != 0 {
+		t.Fatalf("LockedFor after expiry = %v, %v, want 0", locked, err)
+	}
+
+	if err = s.Reset(ctx, "k"); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if locked, err = s.Fail(ctx, "k", lockout); err != nil || locked != 0 {
+		t.Fatalf("Fail after Reset = %v, %v, want no lock", locked, err)
+	}
+}
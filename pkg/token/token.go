@@ -0,0 +1,84 @@
+// Package token issues and verifies the access tokens returned by a login.
+// Tokens are JWTs signed with HMAC-SHA256 and carry the user and role IDs,
+// so requests can be authenticated without a session store.
+package token
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalid is returned for tokens that are malformed, expired or not
+// signed with the issuer's secret.
+var ErrInvalid = errors.New("invalid or expired token")
+
+// Claims identify the user a token was issued to.
+type Claims struct {
+	UserID    int
+	RoleID    int
+	ExpiresAt time.Time
+}
+
+type jwtClaims struct {
+	RoleID int `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Issuer signs and verifies tokens with one secret.
+type Issuer struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewIssuer returns an Issuer whose tokens name issuer and expire after ttl.
+func NewIssuer(secret []byte, issuer string, ttl time.Duration) *Issuer {
+	return &Issuer{secret: secret, issuer: issuer, ttl: ttl, now: time.Now}
+}
+
+// Issue returns a token for the user and when it expires.
+func (i *Issuer) Issue(userID, roleID int) (string, time.Time, error) {
+	now := i.now()
+	expiresAt := now.Add(i.ttl).Truncate(time.Second)
+	claims := jwtClaims{
+		RoleID: roleID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Verify checks the signature, issuer and expiry of a token and returns its
+// claims.
+func (i *Issuer) Verify(signed string) (Claims, error) {
+	var claims jwtClaims
+	_, err := jwt.ParseWithClaims(signed, &claims, func(*jwt.Token) (interface{}, error) {
+		return i.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		return Claims{}, ErrInvalid
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return Claims{}, ErrInvalid
+	}
+	return Claims{UserID: userID, RoleID: claims.RoleID, ExpiresAt: claims.ExpiresAt.Time}, nil
+}
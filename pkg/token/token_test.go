@@ -0,0 +1,72 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte(strings.Repeat("s", 32))
+
+func TestIssueAndVerify(t *testing.T) {
+	issuer := NewIssuer(testSecret, "farm", time.Hour)
+	now := time.Unix(1_700_000_000, 0)
+	issuer.now = func() time.Time { return now }
+
+	signed, expiresAt, err := issuer.Issue(7, 2)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !expiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expiresAt = %v, want %v", expiresAt, now.Add(time.Hour))
+	}
+
+	claims, err := issuer.Verify(signed)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.UserID != 7 || claims.RoleID != 2 || !claims.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("claims = %+v, want user 7 with role 2", claims)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	issuer := NewIssuer(testSecret, "farm", time.Hour)
+	now := time.Unix(1_700_000_000, 0)
+	issuer.now = func() time.Time { return now }
+	signed, _, err := issuer.Issue(7, 2)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	otherSecret := NewIssuer([]byte(strings.Repeat("o", 32)), "farm", time.Hour)
+	otherSecret.now = issuer.now
+	otherIssuer := NewIssuer(testSecret, "elsewhere", time.Hour)
+	otherIssuer.now = issuer.now
+	expired := NewIssuer(testSecret, "farm", time.Hour)
+	expired.now = func() time.Time { return now.Add(time.Hour + time.Second) }
+
+	parts := strings.Split(signed, ".")
+	unsigned := "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + parts[1] + "."
+
+	tests := []struct {
+		name     string
+		verifier *Issuer
+		token    string
+	}{
+		{"other secret", otherSecret, signed},
+		{"other issuer", otherIssuer, signed},
+		{"expired", expired, signed},
+		{"tampered payload", issuer, parts[0] + "." + parts[1] + "x." + parts[2]},
+		{"alg none", issuer, unsigned},
+		{"garbage", issuer, "not a token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.verifier.Verify(tt.token); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Verify = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
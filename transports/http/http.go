@@ -1,10 +1,15 @@
 package http
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -12,14 +17,26 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/sanika-farm/sanika-farm-be/configs"
 	"github.com/sanika-farm/sanika-farm-be/infras"
+	webhooksServices "github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/services"
+	"github.com/sanika-farm/sanika-farm-be/internal/jobs"
+	"github.com/sanika-farm/sanika-farm-be/pkg/errreport"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/health"
 	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/middleware"
 	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
 	"github.com/sanika-farm/sanika-farm-be/transports/http/router"
 	"github.com/shopspring/decimal"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/swaggo/swag/example/basic/docs"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
+// readHeaderTimeout bounds how long a client may take to send the request
+// headers, so slow clients cannot hold connections open.
+const readHeaderTimeout = 10 * time.Second
+
 // ServerState is an indicator if this server's state.
 type ServerState int
 
@@ -34,38 +51,107 @@ const (
 
 // HTTP is the HTTP server.
 type HTTP struct {
-	Config *configs.Config
-	DB     *infras.PostgresConn
-	Router router.Router
-	State  ServerState
-	mux    *gin.Engine
+	Config    *configs.Config
+	DB        *infras.PostgresConn
+	Redis     *infras.RedisConn
+	Health    *health.Registry
+	Metrics   *metrics.Registry
+	Tracer    *sdktrace.TracerProvider
+	Errors    errreport.Reporter
+	Outbox    *infras.OutboxRelay
+	Webhooks  *webhooksServices.Dispatcher
+	Scheduler *infras.Scheduler
+	Router    router.Router
+	state     atomic.Int32
+	cors      atomic.Pointer[configs.CORS]
+	mux       *gin.Engine
+	server    *http.Server
+	hooks     []shutdownHook
+}
+
+// State returns the server's state. It is safe to call from any goroutine.
+func (h *HTTP) State() ServerState {
+	return ServerState(h.state.Load())
+}
+
+func (h *HTTP) setState(state ServerState) {
+	h.state.Store(int32(state))
 }
 
 // ProvideHTTP is the provider for HTTP.
-func ProvideHTTP(db *infras.PostgresConn, config *configs.Config, router router.Router) *HTTP {
-	return &HTTP{
-		DB:     db,
-		Config: config,
-		Router: router,
+func ProvideHTTP(db *infras.PostgresConn, redis *infras.RedisConn, config *configs.Config, router router.Router, healthRegistry *health.Registry, metricsRegistry *metrics.Registry, tracer *sdktrace.TracerProvider, reporter errreport.Reporter, watcher *configs.Watcher, outbox *infras.OutboxRelay, webhooks *webhooksServices.Dispatcher, scheduler *jobs.Scheduler) *HTTP {
+	h := &HTTP{
+		DB:        db,
+		Redis:     redis,
+		Config:    config,
+		Health:    healthRegistry,
+		Metrics:   metricsRegistry,
+		Tracer:    tracer,
+		Errors:    reporter,
+		Outbox:    outbox,
+		Webhooks:  webhooks,
+		Scheduler: scheduler.Scheduler,
+		Router:    router,
 	}
+	h.cors.Store(&config.App.CORS)
+	watcher.OnCORSChanged(func(change configs.CORSChanged) {
+		h.cors.Store(&change.CORS)
+		h.logCORSConfigInfo()
+	})
+	h.OnShutdown("tracing", tracer.Shutdown)
+	h.OnShutdown("postgres", db.Close)
+	h.OnShutdown("redis", redis.Close)
+	h.OnShutdown("outbox relay", outbox.Shutdown)
+	h.OnShutdown("webhook dispatcher", webhooks.Shutdown)
+	h.OnShutdown("scheduler", scheduler.Shutdown)
+	return h
 }
 
-// SetupAndServe sets up the server and gets it up and running.
-func (h *HTTP) SetupAndServe() {
-	h.mux = gin.New()
+// SetupAndServe sets up the server and serves until it receives SIGINT or
+// SIGTERM, then shuts down gracefully. It returns an error if the server could
+// not start or the shutdown did not complete cleanly.
+func (h *HTTP) SetupAndServe() error {
+	mux, err := router.NewEngine(h.Config.Server.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("trusted proxies: %w", err)
+	}
+	h.mux = mux
 	h.setupMiddleware()
 	h.setupSwaggerDocs()
+	h.setupHealthChecks()
+	h.setupMetrics()
 	h.setupRoutes()
-	h.setupGracefulShutdown()
-	h.State = ServerStateReady
+	h.startBackgroundWorkers()
+	h.server = &http.Server{
+		Addr:              ":" + h.Config.Server.Port,
+		Handler:           h.mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	h.setState(ServerStateReady)
 
 	h.logServerInfo()
 
-	log.Info().Str("port", h.Config.Server.Port).Msg("Starting up HTTP server.")
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
 
-	err := h.mux.Run(":" + h.Config.Server.Port)
-	if err != nil {
-		logger.ErrorWithStack(err)
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Info().Str("port", h.Config.Server.Port).Msg("Starting up HTTP server.")
+		serveErr <- h.server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		logger.ErrorWithStack(context.Background(), err)
+		ctx, cancel := h.shutdownContext()
+		defer cancel()
+		ctx, cancelCleanup := context.WithTimeout(ctx, h.cleanupPeriod())
+		defer cancelCleanup()
+		return errors.Join(err, h.runShutdownHooks(ctx))
+	case sig := <-signals:
+		log.Info().Str("signal", sig.String()).Msg("Received shutdown signal.")
+		return h.shutdown(serveErr, signals)
 	}
 }
 
@@ -80,38 +166,68 @@ func (h *HTTP) setupSwaggerDocs() {
 	}
 }
 
-func (h *HTTP) setupRoutes() {
-	decimal.MarshalJSONWithoutQuotes = true
-	h.Router.SetupRoutes(h.mux)
+func (h *HTTP) setupHealthChecks() {
+	healthRoutes := h.mux.Group("/health")
+	{
+		healthRoutes.GET("/live", h.live)
+		healthRoutes.GET("/ready", h.ready)
+	}
+	log.Info().Strs("checks", h.Health.Names()).Msg("Health checks enabled.")
 }
 
-func (h *HTTP) setupGracefulShutdown() {
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
-	go h.respondToSigterm(done)
+// live reports that the process is up and able to serve HTTP.
+func (h *HTTP) live(c *gin.Context) {
+	response.WithMessage(c, http.StatusOK, "SERVER ALIVE")
 }
 
-func (h *HTTP) respondToSigterm(done chan os.Signal) {
-	<-done
-	defer os.Exit(0)
+// ready reports whether the server should receive traffic. It is not ready
+// while shutting down or when any registered check fails.
+func (h *HTTP) ready(c *gin.Context) {
+	if h.State() != ServerStateReady {
+		response.WithPreparingShutdown(c)
+		return
+	}
 
-	shutdownConfig := h.Config.Server.Shutdown
+	report := h.Health.Run(c.Request.Context())
+	if !report.Healthy() {
+		response.WithUnhealthy(c, report)
+		return
+	}
+	response.WithJSON(c, http.StatusOK, report)
+}
 
-	log.Info().Msg("Received SIGTERM.")
-	log.Info().Int64("seconds", shutdownConfig.GracePeriodSeconds).Msg("Entering grace period.")
-	h.State = ServerStateInGracePeriod
-	time.Sleep(time.Duration(shutdownConfig.GracePeriodSeconds) * time.Second)
+func (h *HTTP) setupMetrics() {
+	metricsConfig := h.Config.Server.Metrics
+	if !metricsConfig.Enable {
+		log.Info().Msg("Metrics endpoint is disabled.")
+		return
+	}
 
-	log.Info().Int64("seconds", shutdownConfig.CleanupPeriodSeconds).Msg("Entering cleanup period.")
-	h.State = ServerStateInCleanupPeriod
-	time.Sleep(time.Duration(shutdownConfig.CleanupPeriodSeconds) * time.Second)
+	h.mux.GET("/metrics", func(c *gin.Context) {
+		if metricsConfig.Token != "" && !validBearerToken(c.GetHeader("Authorization"), metricsConfig.Token.Value()) {
+			response.WithError(c, failure.Unauthorized("invalid metrics token"))
+			return
+		}
+		h.Metrics.Handler().ServeHTTP(c.Writer, c.Request)
+	})
+	log.Info().Bool("protected", metricsConfig.Token != "").Msg("Metrics endpoint enabled.")
+}
+
+func validBearerToken(header, token string) bool {
+	return subtle.ConstantTimeCompare([]byte(header), []byte("Bearer "+token)) == 1
+}
 
-	log.Info().Msg("Cleaning up completed. Shutting down now.")
+func (h *HTTP) setupRoutes() {
+	decimal.MarshalJSONWithoutQuotes = true
+	h.Router.SetupRoutes(h.mux)
 }
 
 func (h *HTTP) setupMiddleware() {
-	h.mux.Use(gin.Logger())
-	h.mux.Use(gin.Recovery())
+	h.mux.Use(middleware.Tracing(h.Tracer))
+	h.mux.Use(middleware.RequestLogger())
+	h.mux.Use(middleware.Recovery(h.Errors))
+	h.mux.Use(middleware.Metrics(h.Metrics))
+	h.mux.Use(middleware.ReadYourWrites())
 	h.mux.Use(h.serverStateMiddleware())
 	h.setupCORS()
 }
@@ -121,7 +237,7 @@ func (h *HTTP) logServerInfo() {
 }
 
 func (h *HTTP) logCORSConfigInfo() {
-	corsConfig := h.Config.App.CORS
+	corsConfig := h.cors.Load()
 	if corsConfig.Enable {
 		log.Info().Msg("CORS Headers and Handlers are enabled.")
 		log.Info().Str("CORS Header", fmt.Sprintf("Access-Control-Allow-Credentials: %t", corsConfig.AllowCredentials)).Msg("")
@@ -136,7 +252,7 @@ func (h *HTTP) logCORSConfigInfo() {
 
 func (h *HTTP) serverStateMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		switch h.State {
+		switch h.State() {
 		case ServerStateReady:
 			// Server is ready to serve, proceed as normal.
 			c.Next()
@@ -152,16 +268,18 @@ func (h *HTTP) serverStateMiddleware() gin.HandlerFunc {
 	}
 }
 
+// setupCORS adds the CORS headers. The settings are read on every request, so
+// changes published by the config watcher apply immediately.
 func (h *HTTP) setupCORS() {
-	corsConfig := h.Config.App.CORS
-	if corsConfig.Enable {
-		h.mux.Use(func(c *gin.Context) {
+	h.mux.Use(func(c *gin.Context) {
+		corsConfig := h.cors.Load()
+		if corsConfig.Enable {
 			c.Header("Access-Control-Allow-Credentials", fmt.Sprintf("%t", corsConfig.AllowCredentials))
 			c.Header("Access-Control-Allow-Headers", strings.Join(corsConfig.AllowedHeaders, ","))
 			c.Header("Access-Control-Allow-Methods", strings.Join(corsConfig.AllowedMethods, ","))
 			c.Header("Access-Control-Allow-Origin", strings.Join(corsConfig.AllowedOrigins, ","))
 			c.Header("Access-Control-Max-Age", fmt.Sprintf("%d", corsConfig.MaxAgeSeconds))
-			c.Next()
-		})
-	}
+		}
+		c.Next()
+	})
 }
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/token"
+)
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &configs.Config{}
+	cfg.Auth.AdminRoleID = 1
+	tokens := token.NewIssuer([]byte(strings.Repeat("s", 32)), "farm", time.Hour)
+	auth := ProvideAuthenticator(cfg, tokens)
+
+	var actor *int
+	mux := gin.New()
+	mux.Use(auth.Authenticate())
+	mux.GET("/v1/notifications", func(c *gin.Context) {
+		actor = infras.ActorFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	mux.GET("/v1/audit", auth.RequireAdmin(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	user, _, err := tokens.Issue(7, 2)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	admin, _, err := tokens.Issue(8, 1)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	other, _, err := token.NewIssuer([]byte(strings.Repeat("o", 32)), "farm", time.Hour).Issue(7, 1)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		want          int
+	}{
+		{"no header", "/v1/notifications", "", http.StatusUnauthorized},
+		{"other scheme", "/v1/notifications", "Basic " + user, http.StatusUnauthorized},
+		{"empty token", "/v1/notifications", "Bearer ", http.StatusUnauthorized},
+		{"token of another secret", "/v1/notifications", "Bearer " + other, http.StatusUnauthorized},
+		{"valid token", "/v1/notifications", "Bearer " + user, http.StatusOK},
+		{"lowercase scheme", "/v1/notifications", "bearer " + user, http.StatusOK},
+		{"admin route as user", "/v1/audit", "Bearer " + user, http.StatusForbidden},
+		{"admin route as admin", "/v1/audit", "Bearer " + admin, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor = nil
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.path == "/v1/notifications" && rec.Code == http.StatusOK && (actor == nil || *actor != 7) {
+				t.Errorf("actor = %v, want user 7", actor)
+			}
+		})
+	}
+}
+
+func TestAuthenticatedRequestsCarryTheUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	global := log.Logger
+	log.Logger = zerolog.New(&logs)
+	t.Cleanup(func() { log.Logger = global })
+
+	tokens := token.NewIssuer([]byte(strings.Repeat("s", 32)), "farm", time.Hour)
+	var account string
+	mux := gin.New()
+	mux.Use(RequestLogger(), ReadYourWrites(), ProvideAuthenticator(&configs.Config{}, tokens).Authenticate())
+	mux.GET("/v1/notifications", func(c *gin.Context) {
+		account = AuthenticatedAccount(c)
+		logger.FromContext(c.Request.Context()).Info().Msg("Listing notifications.")
+		c.Status(http.StatusOK)
+	})
+
+	signed, _, err := tokens.Issue(7, 2)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/v1/notifications", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if account != "7" {
+		t.Errorf("AuthenticatedAccount = %q, want 7", account)
+	}
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want the handler's and the request's: %s", len(lines), logs.String())
+	}
+	for _, line := range lines {
+		var entry struct {
+			UserID int `json:"user_id"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.UserID != 7 {
+			t.Errorf("log line %s has no user_id 7", line)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+)
+
+// ReadYourWrites identifies the client by IP for read-your-writes routing,
+// so that reads shortly after the client's own writes are served by the
+// primary. SetUser replaces the key with the user ID once the request is
+// authenticated.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		c.Request = c.Request.WithContext(infras.WithConsistencyKey(c.Request.Context(), key))
+		c.Next()
+	}
+}
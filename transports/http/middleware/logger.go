@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// RequestIDHeader is the header a request ID is accepted from and echoed in.
+	RequestIDHeader = "X-Request-ID"
+	// UserIDKey is the gin context key under which authentication stores the
+	// ID of the calling user, so it shows up in request logs.
+	UserIDKey = "userID"
+)
+
+// validRequestID limits accepted request IDs to a sane length and alphabet,
+// so callers cannot inject arbitrary content into logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestLogger accepts or generates a request ID, attaches a logger carrying
+// it to the request context, and logs each request once it completes.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		fields := log.With().
+			Str("request_id", requestID).
+			Str("method", c.Request.Method).
+			Str("route", route)
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			fields = fields.Str("trace_id", sc.TraceID().String())
+		}
+		requestLogger := fields.Logger()
+
+		ctx := logger.WithRequestID(c.Request.Context(), requestID)
+		c.Request = c.Request.WithContext(logger.WithContext(ctx, requestLogger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		var event *zerolog.Event
+		switch {
+		case status >= 500:
+			event = requestLogger.Error()
+		case status >= 400:
+			event = requestLogger.Warn()
+		default:
+			event = requestLogger.Info()
+		}
+		if userID, ok := c.Get(UserIDKey); ok {
+			event = event.Interface("user_id", userID)
+		}
+		if len(c.Errors) > 0 {
+			event = event.Str("errors", c.Errors.String())
+		}
+		event.
+			Str("path", c.Request.URL.Path).
+			Int("status", status).
+			Float64("latency_ms", float64(time.Since(start).Microseconds())/1000).
+			Int("bytes", c.Writer.Size()).
+			Str("client_ip", c.ClientIP()).
+			Msg("Request completed.")
+	}
+}
+
+func newRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+)
+
+// unmatchedRoute labels requests that did not match any route, so unknown
+// paths cannot blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of requests by method, route
+// template and status.
+func Metrics(registry *metrics.Registry) gin.HandlerFunc {
+	requests := registry.NewCounter("http_requests_total",
+		"Total number of HTTP requests.", "method", "route", "status")
+	latency := registry.NewHistogram("http_request_duration_seconds",
+		"HTTP request latency in seconds.", metrics.DefaultBuckets, "method", "route", "status")
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		requests.Inc(c.Request.Method, route, status)
+		latency.Observe(time.Since(start).Seconds(), c.Request.Method, route, status)
+	}
+}
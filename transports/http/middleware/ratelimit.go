@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+	"github.com/sanika-farm/sanika-farm-be/pkg/ratelimit"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// maxAccountBodyBytes bounds how much of a request body AccountFromJSON
+// reads to find the account.
+const maxAccountBodyBytes = 64 << 10
+
+// RateLimitRule limits the requests of a route group. Every client IP gets
+// its own token bucket, and so does every account when Account finds one.
+type RateLimitRule struct {
+	// Name keeps the buckets of each group apart.
+	Name string
+	// Limit picks the group's limit from the current settings.
+	Limit func(configs.RateLimit) ratelimit.Limit
+	// Account returns the account a request acts on, or an empty string.
+	Account func(c *gin.Context) string
+	// Lockout locks the account, or the IP when there is no account, out
+	// after repeated failed attempts, which are requests answered with 401.
+	Lockout bool
+}
+
+// DefaultRateLimit is the SERVER.RATE_LIMIT limit.
+func DefaultRateLimit(cfg configs.RateLimit) ratelimit.Limit {
+	return ratelimit.Limit{PerMinute: cfg.RequestsPerMinute, Burst: cfg.Burst}
+}
+
+// AuthRateLimit is the SERVER.RATE_LIMIT.AUTH limit, for endpoints such as
+// registration and login that are targets of brute force.
+func AuthRateLimit(cfg configs.RateLimit) ratelimit.Limit {
+	return ratelimit.Limit{PerMinute: cfg.Auth.RequestsPerMinute, Burst: cfg.Auth.Burst}
+}
+
+// AuthenticatedAccount returns the ID of the authenticated user.
+func AuthenticatedAccount(c *gin.Context) string {
+	if userID, ok := c.Get(UserIDKey); ok {
+		return fmt.Sprint(userID)
+	}
+	return ""
+}
+
+// AccountFromJSON returns an Account func reading the account from a string
+// field of the JSON body, such as the username of a login. The body is put
+// back for the handler.
+func AccountFromJSON(field string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxAccountBodyBytes))
+		c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(body), c.Request.Body), c.Request.Body}
+		if err != nil {
+			return ""
+		}
+
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(body, &fields) != nil {
+			return ""
+		}
+		var account string
+		if json.Unmarshal(fields[field], &account) != nil {
+			return ""
+		}
+		return strings.ToLower(strings.TrimSpace(account))
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// RateLimiter enforces rate limit rules. Its settings follow SERVER.RATE_LIMIT
+// and can change without a restart.
+type RateLimiter struct {
+	store   ratelimit.Store
+	cfg     atomic.Pointer[configs.RateLimit]
+	limited *metrics.Counter
+}
+
+// ProvideRateLimiter is the provider for the rate limiter. Buckets are kept
+// in Redis when it is configured, so limits hold across replicas, and in
+// memory otherwise.
+func ProvideRateLimiter(config *configs.Config, watcher *configs.Watcher, redis *infras.RedisConn, registry *metrics.Registry) *RateLimiter {
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if redis.Enabled() {
+		store = ratelimit.NewRedisStore(redis.Client())
+	}
+
+	l := &RateLimiter{
+		store: store,
+		limited: registry.NewCounter("http_rate_limited_total",
+			"Total number of requests rejected by rate limits, by rule and scope.", "rule", "scope"),
+	}
+	l.cfg.Store(&config.Server.RateLimit)
+	watcher.OnRateLimitChanged(func(change configs.RateLimitChanged) {
+		l.cfg.Store(&change.RateLimit)
+		log.Info().Bool("enable", change.RateLimit.Enable).Msg("Rate limits changed.")
+	})
+	return l
+}
+
+// Handler returns the middleware enforcing rule. Rejected requests get 429
+// with Retry-After. When the store fails, requests are let through rather
+// than failing the API.
+func (l *RateLimiter) Handler(rule RateLimitRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := l.cfg.Load()
+		if !cfg.Enable {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+
+		var account string
+		if rule.Account != nil {
+			account = rule.Account(c)
+		}
+		ipKey := rule.Name + ":ip:" + c.ClientIP()
+		lockKey := ipKey
+		if account != "" {
+			lockKey = rule.Name + ":account:" + account
+		}
+
+		if rule.Lockout {
+			if locked := l.lockedFor(ctx, lockKey); locked > 0 {
+				l.limited.Inc(rule.Name, "lockout")
+				response.WithTooManyRequests(c, locked, "too many failed attempts, try again later")
+				c.Abort()
+				return
+			}
+		}
+
+		limit := rule.Limit(*cfg)
+		if !l.allow(c, rule.Name, "ip", ipKey, limit) {
+			return
+		}
+		if account != "" && !l.allow(c, rule.Name, "account", rule.Name+":account:"+account, limit) {
+			return
+		}
+
+		c.Next()
+
+		if rule.Lockout {
+			l.recordAttempt(ctx, lockKey, c.Writer.Status(), cfg)
+		}
+	}
+}
+
+// allow takes a token for key and rejects the request when there is none.
+func (l *RateLimiter) allow(c *gin.Context, rule, scope, key string, limit ratelimit.Limit) bool {
+	res, err := l.store.Allow(c.Request.Context(), key, limit)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn().Err(err).Str("rule", rule).Msg("Rate limit check failed, allowing request.")
+		return true
+	}
+	if !res.Allowed {
+		l.limited.Inc(rule, scope)
+		response.WithTooManyRequests(c, res.RetryAfter, "too many requests, slow down")
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+func (l *RateLimiter) lockedFor(ctx context.Context, key string) time.Duration {
+	locked, err := l.store.LockedFor(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx).Warn().Err(err).Msg("Lockout check failed, allowing request.")
+		return 0
+	}
+	return locked
+}
+
+// recordAttempt counts a 401 as a failed attempt and forgets earlier
+// failures after a success.
+func (l *RateLimiter) recordAttempt(ctx context.Context, key string, status int, cfg *configs.RateLimit) {
+	switch {
+	case status == http.StatusUnauthorized:
+		lockout := ratelimit.Lockout{
+			MaxFailures: cfg.Lockout.MaxFailures,
+			Duration:    cfg.Lockout.Duration,
+			MaxDuration: cfg.Lockout.MaxDuration,
+		}
+		locked, err := l.store.Fail(ctx, key, lockout)
+		if err != nil {
+			logger.FromContext(ctx).Warn().Err(err).Msg("Failed to record failed attempt.")
+			return
+		}
+		if locked > 0 {
+			logger.FromContext(ctx).Warn().Str("key", key).Dur("lockedFor", locked).Msg("Locked out after repeated failed attempts.")
+		}
+	case status >= 200 && status < 300:
+		if err := l.store.Reset(ctx, key); err != nil {
+			logger.FromContext(ctx).Warn().Err(err).Msg("Failed to reset failed attempts.")
+		}
+	}
+}
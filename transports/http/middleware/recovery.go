@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/pkg/errreport"
+	"github.com/sanika-farm/sanika-farm-be/pkg/logger"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/response"
+)
+
+// Recovery recovers from panics in later handlers. It logs the panic with
+// its stack through the request logger, reports it, and responds with the
+// standard internal error envelope.
+func Recovery(reporter errreport.Reporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			err, ok := recovered.(error)
+			if !ok {
+				err = fmt.Errorf("%v", recovered)
+			}
+			ctx := c.Request.Context()
+
+			// The client went away, so there is nobody to respond to and
+			// nothing worth reporting.
+			if isBrokenPipe(err) {
+				logger.FromContext(ctx).Warn().Err(err).Msg("Connection closed by client.")
+				_ = c.Error(err)
+				c.Abort()
+				return
+			}
+
+			logger.ErrorWithStack(ctx, fmt.Errorf("panic recovered: %w", err))
+			reporter.Report(ctx, err, debug.Stack())
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			response.WithInternalError(c)
+			c.Abort()
+		}()
+		c.Next()
+	}
+}
+
+func isBrokenPipe(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if !errors.As(opErr, &syscallErr) {
+		return false
+	}
+	msg := strings.ToLower(syscallErr.Error())
+	return strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer")
+}
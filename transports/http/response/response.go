@@ -1,7 +1,10 @@
 package response
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
@@ -30,7 +33,8 @@ func WithJSON(c *gin.Context, code int, jsonPayload interface{}) {
 	respond(c, code, Base{Data: &jsonPayload})
 }
 
-// WithMetadata sends a response containing a JSON object with metadata
+// WithMetadata sends a response containing a JSON object with metadata.
+// List endpoints should pass a pagination.Metadata.
 func WithMetadata(c *gin.Context, code int, jsonPayload interface{}, metadata interface{}) {
 	respond(c, code, Base{Data: &jsonPayload, Metadata: &metadata})
 }
@@ -42,14 +46,33 @@ func WithError(c *gin.Context, err error) {
 	respond(c, code, Base{Error: &errMsg})
 }
 
+// WithInternalError sends a generic internal server error, revealing nothing
+// about its cause
+func WithInternalError(c *gin.Context) {
+	errMsg := failure.ErrInternalError.Error()
+	respond(c, http.StatusInternalServerError, Base{Error: &errMsg})
+}
+
+// WithTooManyRequests rejects a rate-limited request, telling the client in
+// Retry-After how many seconds to wait before trying again.
+func WithTooManyRequests(c *gin.Context, retryAfter time.Duration, message string) {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.FormatInt(seconds, 10))
+	respond(c, http.StatusTooManyRequests, Base{Error: &message})
+}
+
 // WithPreparingShutdown sends a default response for when the server is preparing to shut down
 func WithPreparingShutdown(c *gin.Context) {
 	WithMessage(c, http.StatusServiceUnavailable, "SERVER PREPARING TO SHUT DOWN")
 }
 
-// WithUnhealthy sends a default response for when the server is unhealthy
-func WithUnhealthy(c *gin.Context) {
-	WithMessage(c, http.StatusServiceUnavailable, "SERVER UNHEALTHY")
+// WithUnhealthy sends a default response for when the server is unhealthy, with details of the failed checks
+func WithUnhealthy(c *gin.Context, details interface{}) {
+	message := "SERVER UNHEALTHY"
+	respond(c, http.StatusServiceUnavailable, Base{Data: &details, Message: &message})
 }
 
 func respond(c *gin.Context, code int, payload interface{}) {
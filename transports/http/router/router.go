@@ -3,29 +3,81 @@ package router
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/sanika-farm/sanika-farm-be/internal/handlers"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/middleware"
 )
 
 // DomainHandlers is a struct that contains all domain-specific handlers.
 type DomainHandlers struct {
-	UsersHandler handlers.UsersHandler
+	UsersHandler         handlers.UsersHandler
+	ExpensesHandler      handlers.ExpensesHandler
+	PurchasingHandler    handlers.PurchasingHandler
+	MortalityHandler     handlers.MortalityHandler
+	ProcessingHandler    handlers.ProcessingHandler
+	AuditHandler         handlers.AuditHandler
+	WebhooksHandler      handlers.WebhooksHandler
+	NotificationsHandler handlers.NotificationsHandler
 }
 
 // Router is the router struct containing handlers.
 type Router struct {
 	DomainHandlers DomainHandlers
+	RateLimiter    *middleware.RateLimiter
+	Authenticator  *middleware.Authenticator
 }
 
 // ProvideRouter is the provider function for this router.
-func ProvideRouter(domainHandlers DomainHandlers) Router {
+func ProvideRouter(domainHandlers DomainHandlers, rateLimiter *middleware.RateLimiter, authenticator *middleware.Authenticator) Router {
 	return Router{
 		DomainHandlers: domainHandlers,
+		RateLimiter:    rateLimiter,
+		Authenticator:  authenticator,
 	}
 }
 
-// SetupRoutes sets up all routing for this server.
+// NewEngine returns a gin engine that believes X-Forwarded-For and X-Real-IP
+// only from trustedProxies, so clients cannot pick the IP they are rate
+// limited and logged by. With no trusted proxies the client IP is always the
+// connection's address.
+func NewEngine(trustedProxies []string) (*gin.Engine, error) {
+	engine := gin.New()
+	if len(trustedProxies) == 0 {
+		trustedProxies = nil
+	}
+	if err := engine.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+// SetupRoutes sets up all routing for this server. The authentication
+// routes are open and limited per client IP and per account, with lockout
+// after repeated failures. Every other route needs a bearer token and is
+// limited per client IP and per user; the audit trail also needs the
+// administrator role.
 func (r *Router) SetupRoutes(router *gin.Engine) {
 	v1 := router.Group("/v1")
+	auth := v1.Group("", r.RateLimiter.Handler(middleware.RateLimitRule{
+		Name:    "auth",
+		Limit:   middleware.AuthRateLimit,
+		Account: middleware.AccountFromJSON("username"),
+		Lockout: true,
+	}))
+	{
+		r.DomainHandlers.UsersHandler.AuthRouter(auth)
+	}
+	api := v1.Group("", r.Authenticator.Authenticate(), r.RateLimiter.Handler(middleware.RateLimitRule{
+		Name:    "api",
+		Limit:   middleware.DefaultRateLimit,
+		Account: middleware.AuthenticatedAccount,
+	}))
 	{
-		r.DomainHandlers.UsersHandler.Router(v1)
+		r.DomainHandlers.UsersHandler.Router(api)
+		r.DomainHandlers.ExpensesHandler.Router(api)
+		r.DomainHandlers.PurchasingHandler.Router(api)
+		r.DomainHandlers.MortalityHandler.Router(api)
+		r.DomainHandlers.ProcessingHandler.Router(api)
+		r.DomainHandlers.AuditHandler.Router(api.Group("", r.Authenticator.RequireAdmin()))
+		r.DomainHandlers.WebhooksHandler.Router(api)
+		r.DomainHandlers.NotificationsHandler.Router(api)
 	}
 }
@@ -0,0 +1,354 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sanika-farm/sanika-farm-be/configs"
+	"github.com/sanika-farm/sanika-farm-be/infras"
+	"github.com/sanika-farm/sanika-farm-be/infras/infrastest"
+	auditDto "github.com/sanika-farm/sanika-farm-be/internal/domain/audit/model/dto"
+	auditServices "github.com/sanika-farm/sanika-farm-be/internal/domain/audit/services"
+	notificationsModel "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/model"
+	notificationsRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/repository"
+	notificationsServices "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/services"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/model/dto"
+	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/services"
+	"github.com/sanika-farm/sanika-farm-be/internal/handlers"
+	"github.com/sanika-farm/sanika-farm-be/pkg/events"
+	"github.com/sanika-farm/sanika-farm-be/pkg/failure"
+	"github.com/sanika-farm/sanika-farm-be/pkg/metrics"
+	"github.com/sanika-farm/sanika-farm-be/pkg/pagination"
+	"github.com/sanika-farm/sanika-farm-be/pkg/token"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/middleware"
+)
+
+const testPassword = "correct horse"
+
+// testUsers are the accounts fakeUsersService knows, by lowercase username.
+var testUsers = map[string]dto.UserResponse{
+	"budi":  {ID: 1, Username: "budi", RoleID: 2},
+	"siti":  {ID: 2, Username: "siti", RoleID: 2},
+	"admin": {ID: 3, Username: "admin", RoleID: 1},
+}
+
+// fakeUsersService accepts testPassword for the testUsers, and treats
+// "taken" as an existing username.
+type fakeUsersService struct {
+	services.UsersService
+	tokens *token.Issuer
+}
+
+func (fakeUsersService) CreateUser(_ context.Context, req *dto.CreateUserRequest) (dto.UserResponse, error) {
+	if req.Username == "taken" {
+		return dto.UserResponse{}, failure.Conflict("register", "user", "username is already taken")
+	}
+	return dto.UserResponse{ID: 1, Username: req.Username, RoleID: req.RoleID}, nil
+}
+
+func (f fakeUsersService) Login(_ context.Context, req *dto.LoginRequest) (dto.LoginResponse, error) {
+	user, ok := testUsers[strings.ToLower(req.Username)]
+	if !ok || req.Password != testPassword {
+		return dto.LoginResponse{}, failure.Unauthorized("invalid username or password")
+	}
+	signed, expiresAt, err := f.tokens.Issue(user.ID, user.RoleID)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+	return dto.LoginResponse{Token: signed, ExpiresAt: expiresAt, User: user}, nil
+}
+
+// fakeInbox keeps notifications in memory and lists those matching the
+// user filter the inbox service adds.
+type fakeInbox struct {
+	notificationsRepository.NotificationsRepository
+	notifications []notificationsModel.Notification
+}
+
+func (f *fakeInbox) ListNotifications(_ context.Context, params pagination.Params) ([]notificationsModel.Notification, int, error) {
+	var userID interface{}
+	for _, filter := range params.Filters {
+		if filter.Field == "userId" {
+			userID = filter.Value
+		}
+	}
+	var res []notificationsModel.Notification
+	for _, n := range f.notifications {
+		if userID == n.UserID {
+			res = append(res, n)
+		}
+	}
+	return res, len(res), nil
+}
+
+func newTestConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.App.Name = "farm"
+	cfg.Auth.TokenSecret = configs.Secret(strings.Repeat("s", 32))
+	cfg.Auth.TokenTTL = time.Hour
+	cfg.Auth.AdminRoleID = 1
+	cfg.Notifications.DefaultLocale = "en"
+	cfg.Server.RateLimit.Enable = true
+	cfg.Server.RateLimit.RequestsPerMinute = 1000
+	cfg.Server.RateLimit.Burst = 1000
+	cfg.Server.RateLimit.Auth.RequestsPerMinute = 1000
+	cfg.Server.RateLimit.Auth.Burst = 1000
+	cfg.Server.RateLimit.Lockout.MaxFailures = 3
+	cfg.Server.RateLimit.Lockout.Duration = time.Minute
+	cfg.Server.RateLimit.Lockout.MaxDuration = time.Hour
+	return cfg
+}
+
+func newTestEngine(t *testing.T, cfg *configs.Config, domainHandlers DomainHandlers) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	registry := metrics.NewRegistry()
+	tokens := infras.ProvideTokenIssuer(cfg)
+
+	if domainHandlers.NotificationsHandler.NotificationsService == nil {
+		notifications, err := notificationsServices.ProvideNotificationsService(&fakeInbox{}, cfg, events.NewBus(), registry)
+		if err != nil {
+			t.Fatalf("ProvideNotificationsService: %v", err)
+		}
+		domainHandlers.NotificationsHandler = handlers.ProvideNotificationsHandler(notifications)
+	}
+	domainHandlers.UsersHandler = handlers.ProvideUsersHandler(fakeUsersService{tokens: tokens})
+
+	r := ProvideRouter(domainHandlers,
+		middleware.ProvideRateLimiter(cfg, &configs.Watcher{}, nil, registry),
+		middleware.ProvideAuthenticator(cfg, tokens))
+	engine, err := NewEngine(cfg.Server.TrustedProxies)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	r.SetupRoutes(engine)
+	return engine
+}
+
+func request(engine *gin.Engine, method, path, bearer, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func post(engine *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	return request(engine, http.MethodPost, path, "", body)
+}
+
+// login logs in as username and returns the access token.
+func login(t *testing.T, engine *gin.Engine, username string) string {
+	t.Helper()
+	rec := post(engine, "/v1/users/login", `{"username":"`+username+`","password":"`+testPassword+`"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login as %s = %d %s, want 200", username, rec.Code, rec.Body)
+	}
+	var res struct {
+		Data dto.LoginResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || res.Data.Token == "" {
+		t.Fatalf("login as %s returned no token: %s", username, rec.Body)
+	}
+	return res.Data.Token
+}
+
+func TestLoginLocksOutAfterRepeatedFailures(t *testing.T) {
+	engine := newTestEngine(t, newTestConfig(), DomainHandlers{})
+
+	for i := 0; i < 3; i++ {
+		if rec := post(engine, "/v1/users/login", `{"username":"Budi","password":"wrong"}`); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("failed login %d = %d, want 401", i+1, rec.Code)
+		}
+	}
+
+	// The account is locked, whatever the case of the username and even
+	// with the right password.
+	rec := post(engine, "/v1/users/login", `{"username":"budi","password":"`+testPassword+`"}`)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("login while locked out = %d with Retry-After %q, want 429", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// Other accounts can still log in.
+	if rec := post(engine, "/v1/users/login", `{"username":"siti","password":"`+testPassword+`"}`); rec.Code != http.StatusOK {
+		t.Errorf("login of another account = %d, want 200", rec.Code)
+	}
+}
+
+func TestLoginSuccessForgetsFailures(t *testing.T) {
+	engine := newTestEngine(t, newTestConfig(), DomainHandlers{})
+
+	for round := 0; round < 2; round++ {
+		for i := 0; i < 2; i++ {
+			post(engine, "/v1/users/login", `{"username":"budi","password":"wrong"}`)
+		}
+		if rec := post(engine, "/v1/users/login", `{"username":"budi","password":"`+testPassword+`"}`); rec.Code != http.StatusOK {
+			t.Fatalf("login in round %d = %d, want 200", round+1, rec.Code)
+		}
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	engine := newTestEngine(t, newTestConfig(), DomainHandlers{})
+
+	if rec := post(engine, "/v1/users/register", `{"username":"taken","password":"`+testPassword+`","roleId":1}`); rec.Code != http.StatusConflict {
+		t.Errorf("registering a taken username = %d, want 409", rec.Code)
+	}
+	if rec := post(engine, "/v1/users/register", `{"username":`); rec.Code != http.StatusBadRequest {
+		t.Errorf("registering with a malformed body = %d, want 400", rec.Code)
+	}
+	rec := post(engine, "/v1/users/register", `{"username":"budi","password":"`+testPassword+`","roleId":1}`)
+	if rec.Code != http.StatusCreated || strings.Contains(rec.Body.String(), testPassword) {
+		t.Errorf("register = %d %s, want 201 without the password", rec.Code, rec.Body)
+	}
+}
+
+func TestReadInbox(t *testing.T) {
+	inbox := &fakeInbox{notifications: []notificationsModel.Notification{
+		{ID: 1, UserID: 1, Type: notificationsModel.TypeLowFeed, Title: "Feed is low"},
+		{ID: 2, UserID: 2, Type: notificationsModel.TypeLowFeed, Title: "Not for budi"},
+		{ID: 3, UserID: 1, Type: notificationsModel.TypeMortalityRecorded, Title: "Mortality recorded"},
+	}}
+	cfg := newTestConfig()
+	notifications, err := notificationsServices.ProvideNotificationsService(inbox, cfg, events.NewBus(), metrics.NewRegistry())
+	if err != nil {
+		t.Fatalf("ProvideNotificationsService: %v", err)
+	}
+	engine := newTestEngine(t, cfg, DomainHandlers{
+		NotificationsHandler: handlers.ProvideNotificationsHandler(notifications),
+	})
+
+	for name, bearer := range map[string]string{"no token": "", "invalid token": "not-a-token"} {
+		if rec := request(engine, http.MethodGet, "/v1/notifications", bearer, ""); rec.Code != http.StatusUnauthorized {
+			t.Errorf("reading the inbox with %s = %d, want 401", name, rec.Code)
+		}
+	}
+
+	rec := request(engine, http.MethodGet, "/v1/notifications", login(t, engine, "budi"), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reading the inbox = %d %s, want 200", rec.Code, rec.Body)
+	}
+	var res struct {
+		Data []struct {
+			ID    int64  `json:"id"`
+			Title string `json:"title"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decoding the inbox: %v", err)
+	}
+	var ids []int64
+	for _, n := range res.Data {
+		ids = append(ids, n.ID)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("budi's inbox has notifications %v, want [1 3]", ids)
+	}
+}
+
+func TestAuditRecordsTheAuthenticatedUser(t *testing.T) {
+	db, rec := infrastest.NewPostgresConn(t)
+	cfg := newTestConfig()
+	notifications, err := notificationsServices.ProvideNotificationsService(
+		notificationsRepository.ProvideNotificationsRepository(db), cfg, events.NewBus(), metrics.NewRegistry())
+	if err != nil {
+		t.Fatalf("ProvideNotificationsService: %v", err)
+	}
+	engine := newTestEngine(t, cfg, DomainHandlers{
+		NotificationsHandler: handlers.ProvideNotificationsHandler(notifications),
+	})
+
+	res := request(engine, http.MethodPut, "/v1/notifications/preferences", login(t, engine, "siti"),
+		`{"locale":"id","types":[{"type":"farm.low_feed","email":false,"inApp":true}]}`)
+	if res.Code != http.StatusOK {
+		t.Fatalf("updating preferences = %d %s, want 200", res.Code, res.Body)
+	}
+	audits := rec.Statements("INSERT INTO audit_log")
+	if len(audits) != 1 {
+		t.Fatalf("recorded %d audit rows, want 1", len(audits))
+	}
+	if actor := audits[0].Args[0]; actor != int64(testUsers["siti"].ID) {
+		t.Errorf("audit actor = %v, want siti's ID %d", actor, testUsers["siti"].ID)
+	}
+}
+
+// fakeAudit serves an empty audit trail.
+type fakeAudit struct {
+	auditServices.AuditService
+}
+
+func (fakeAudit) ListAuditEntries(context.Context, pagination.Params) ([]auditDto.AuditEntryResponse, pagination.Metadata, error) {
+	return []auditDto.AuditEntryResponse{}, pagination.Metadata{}, nil
+}
+
+func TestAuditTrailNeedsAdministrator(t *testing.T) {
+	engine := newTestEngine(t, newTestConfig(), DomainHandlers{
+		AuditHandler: handlers.ProvideAuditHandler(fakeAudit{}),
+	})
+
+	tests := []struct {
+		name   string
+		bearer string
+		want   int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"user", login(t, engine, "budi"), http.StatusForbidden},
+		{"administrator", login(t, engine, "admin"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := request(engine, http.MethodGet, "/v1/audit", tt.bearer, ""); rec.Code != tt.want {
+				t.Errorf("reading the audit trail = %d %s, want %d", rec.Code, rec.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPIsOnlyTakenFromTrustedProxies(t *testing.T) {
+	register := func(engine *gin.Engine, remoteAddr, forwardedFor string, i int) int {
+		body := fmt.Sprintf(`{"username":"farmer%d","password":"%s","roleId":1}`, i, testPassword)
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	limitedConfig := func(trustedProxies ...string) *configs.Config {
+		cfg := newTestConfig()
+		cfg.Server.RateLimit.Auth.RequestsPerMinute = 1
+		cfg.Server.RateLimit.Auth.Burst = 2
+		cfg.Server.TrustedProxies = trustedProxies
+		return cfg
+	}
+
+	// Without trusted proxies, a client changing X-Forwarded-For on every
+	// request still shares one limit.
+	engine := newTestEngine(t, limitedConfig(), DomainHandlers{})
+	var codes []int
+	for i := 0; i < 3; i++ {
+		codes = append(codes, register(engine, "198.51.100.7:4000", fmt.Sprintf("203.0.113.%d", i+1), i))
+	}
+	if codes[0] != http.StatusCreated || codes[1] != http.StatusCreated || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("registrations with spoofed X-Forwarded-For = %v, want [201 201 429]", codes)
+	}
+
+	// Behind a trusted proxy, each forwarded client gets its own limit.
+	engine = newTestEngine(t, limitedConfig("198.51.100.0/24"), DomainHandlers{})
+	for i := 0; i < 3; i++ {
+		if code := register(engine, "198.51.100.7:4000", fmt.Sprintf("203.0.113.%d", i+1), i); code != http.StatusCreated {
+			t.Errorf("registration %d forwarded by a trusted proxy = %d, want 201", i+1, code)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ShutdownHook releases a resource when the server shuts down. It should
+// return once the resource is released or the context is done.
+type ShutdownHook func(ctx context.Context) error
+
+type shutdownHook struct {
+	name string
+	fn   ShutdownHook
+}
+
+// OnShutdown registers a hook that runs after in-flight requests have been
+// drained. Hooks run in reverse order of registration, so anything registered
+// after a dependency is stopped before the dependency is closed.
+func (h *HTTP) OnShutdown(name string, fn ShutdownHook) {
+	h.hooks = append(h.hooks, shutdownHook{name: name, fn: fn})
+}
+
+// shutdown keeps serving through the grace period while reporting not ready,
+// then stops accepting connections, drains in-flight requests and runs the
+// shutdown hooks. Draining and hooks share the cleanup period as deadline. A
+// second signal ends the grace period early, and SERVER.SHUTDOWN.TIMEOUT
+// bounds the whole sequence.
+func (h *HTTP) shutdown(serveErr <-chan error, signals <-chan os.Signal) error {
+	ctx, cancel := h.shutdownContext()
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.drain(ctx, serveErr, signals)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			log.Error().Err(err).Msg("Shut down with errors.")
+			return err
+		}
+		log.Info().Msg("Cleaning up completed. Shutting down now.")
+		return nil
+	case <-ctx.Done():
+		err := fmt.Errorf("shutdown did not complete within %s", h.Config.Server.Shutdown.Timeout)
+		log.Error().Err(err).Msg("Shutdown timed out.")
+		return err
+	}
+}
+
+func (h *HTTP) drain(ctx context.Context, serveErr <-chan error, signals <-chan os.Signal) error {
+	shutdownConfig := h.Config.Server.Shutdown
+
+	log.Info().Int64("seconds", shutdownConfig.GracePeriodSeconds).Msg("Entering grace period.")
+	h.setState(ServerStateInGracePeriod)
+	grace := time.NewTimer(time.Duration(shutdownConfig.GracePeriodSeconds) * time.Second)
+	defer grace.Stop()
+	select {
+	case <-grace.C:
+	case sig := <-signals:
+		log.Warn().Str("signal", sig.String()).Msg("Received another signal, ending grace period early.")
+	case <-ctx.Done():
+	}
+
+	log.Info().Int64("seconds", shutdownConfig.CleanupPeriodSeconds).Msg("Entering cleanup period.")
+	h.setState(ServerStateInCleanupPeriod)
+	ctx, cancel := context.WithTimeout(ctx, h.cleanupPeriod())
+	defer cancel()
+
+	var errs []error
+	if err := h.server.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed draining in-flight requests.")
+		errs = append(errs, fmt.Errorf("http server: %w", err))
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, fmt.Errorf("http server: %w", err))
+	}
+	errs = append(errs, h.runShutdownHooks(ctx))
+	return errors.Join(errs...)
+}
+
+func (h *HTTP) runShutdownHooks(ctx context.Context) error {
+	var errs []error
+	for i := len(h.hooks) - 1; i >= 0; i-- {
+		hook := h.hooks[i]
+		if err := hook.fn(ctx); err != nil {
+			log.Error().Err(err).Str("hook", hook.name).Msg("Shutdown hook failed.")
+			errs = append(errs, fmt.Errorf("%s: %w", hook.name, err))
+			continue
+		}
+		log.Info().Str("hook", hook.name).Msg("Shutdown hook completed.")
+	}
+	return errors.Join(errs...)
+}
+
+func (h *HTTP) cleanupPeriod() time.Duration {
+	return time.Duration(h.Config.Server.Shutdown.CleanupPeriodSeconds) * time.Second
+}
+
+// shutdownContext returns the context bounding a shutdown by
+// SERVER.SHUTDOWN.TIMEOUT.
+func (h *HTTP) shutdownContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), h.Config.Server.Shutdown.Timeout)
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunWorker runs the background workers without serving HTTP, until it
+// receives SIGINT or SIGTERM. It lets jobs and deliveries run in their own
+// process, apart from the API replicas.
+func (h *HTTP) RunWorker() error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	h.startBackgroundWorkers()
+	log.Info().Str("env", h.Config.Server.Env).Msg("Worker started.")
+
+	sig := <-signals
+	log.Info().Str("signal", sig.String()).Msg("Received shutdown signal.")
+
+	ctx, cancel := h.shutdownContext()
+	defer cancel()
+	ctx, cancelCleanup := context.WithTimeout(ctx, h.cleanupPeriod())
+	defer cancelCleanup()
+	if err := h.runShutdownHooks(ctx); err != nil {
+		log.Error().Err(err).Msg("Shut down with errors.")
+		return err
+	}
+	log.Info().Msg("Cleaning up completed. Shutting down now.")
+	return nil
+}
+
+// startBackgroundWorkers starts the outbox relay, the webhook dispatcher and,
+// unless JOBS.ENABLE is off, the job scheduler.
+func (h *HTTP) startBackgroundWorkers() {
+	h.Outbox.Start()
+	h.Webhooks.Start()
+	if h.Config.Jobs.Enable {
+		h.Scheduler.Start()
+	} else {
+		log.Info().Msg("Scheduled jobs are disabled.")
+	}
+}
@@ -8,21 +8,48 @@ import (
 
 	"github.com/sanika-farm/sanika-farm-be/configs"
 	"github.com/sanika-farm/sanika-farm-be/infras"
+	auditRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/audit/repository"
+	auditService "github.com/sanika-farm/sanika-farm-be/internal/domain/audit/services"
+	expensesRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/repository"
+	expensesService "github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/services"
+	mortalityRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/repository"
+	mortalityService "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/services"
+	notificationsRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/repository"
+	notificationsService "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/services"
+	processingRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/processing/repository"
+	processingService "github.com/sanika-farm/sanika-farm-be/internal/domain/processing/services"
+	purchasingRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/repository"
+	purchasingService "github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/services"
 	usersRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/users/repository"
 	usersService "github.com/sanika-farm/sanika-farm-be/internal/domain/users/services"
+	webhooksRepository "github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/repository"
+	webhooksService "github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/services"
 	usersHandlers "github.com/sanika-farm/sanika-farm-be/internal/handlers"
+	"github.com/sanika-farm/sanika-farm-be/internal/jobs"
 	"github.com/sanika-farm/sanika-farm-be/transports/http"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/middleware"
 	"github.com/sanika-farm/sanika-farm-be/transports/http/router"
 )
 
 // Wiring for configurations.
 var configurationsService = wire.NewSet(
 	configs.Get,
+	configs.Watch,
 )
 
 // Wiring for persistences.
 var persistencesService = wire.NewSet(
 	infras.ProvidePostgresConn,
+	infras.ProvideRedisConn,
+	infras.ProvideCache,
+	infras.ProvideMetricsRegistry,
+	infras.ProvideHealthRegistry,
+	infras.ProvideTracer,
+	infras.ProvideErrorReporter,
+	infras.ProvideEventBus,
+	infras.ProvideOutboxRelay,
+	infras.ProvideScheduler,
+	infras.ProvideTokenIssuer,
 )
 
 // Wiring for domain users
@@ -34,26 +61,113 @@ var domainUsersService = wire.NewSet(
 	wire.Bind(new(usersRepository.UsersRepository), new(*usersRepository.UsersRepositoryImpl)),
 )
 
+// Wiring for domain expenses
+var domainExpensesService = wire.NewSet(
+	expensesService.ProvideExpensesService,
+	wire.Bind(new(expensesService.ExpensesService), new(*expensesService.ExpensesServiceImpl)),
+
+	expensesRepository.ProvideExpensesRepository,
+	wire.Bind(new(expensesRepository.ExpensesRepository), new(*expensesRepository.ExpensesRepositoryImpl)),
+)
+
+// Wiring for domain purchasing
+var domainPurchasingService = wire.NewSet(
+	purchasingService.ProvidePurchasingService,
+	wire.Bind(new(purchasingService.PurchasingService), new(*purchasingService.PurchasingServiceImpl)),
+
+	purchasingRepository.ProvidePurchasingRepository,
+	wire.Bind(new(purchasingRepository.PurchasingRepository), new(*purchasingRepository.PurchasingRepositoryImpl)),
+)
+
+// Wiring for domain mortality
+var domainMortalityService = wire.NewSet(
+	mortalityService.ProvideMortalityService,
+	wire.Bind(new(mortalityService.MortalityService), new(*mortalityService.MortalityServiceImpl)),
+
+	mortalityRepository.ProvideMortalityRepository,
+	wire.Bind(new(mortalityRepository.MortalityRepository), new(*mortalityRepository.MortalityRepositoryImpl)),
+)
+
+// Wiring for domain processing
+var domainProcessingService = wire.NewSet(
+	processingService.ProvideProcessingService,
+	wire.Bind(new(processingService.ProcessingService), new(*processingService.ProcessingServiceImpl)),
+
+	processingRepository.ProvideProcessingRepository,
+	wire.Bind(new(processingRepository.ProcessingRepository), new(*processingRepository.ProcessingRepositoryImpl)),
+)
+
+// Wiring for domain audit
+var domainAuditService = wire.NewSet(
+	auditService.ProvideAuditService,
+	wire.Bind(new(auditService.AuditService), new(*auditService.AuditServiceImpl)),
+
+	auditRepository.ProvideAuditRepository,
+	wire.Bind(new(auditRepository.AuditRepository), new(*auditRepository.AuditRepositoryImpl)),
+)
+
+// Wiring for domain webhooks
+var domainWebhooksService = wire.NewSet(
+	webhooksService.ProvideWebhooksService,
+	wire.Bind(new(webhooksService.WebhooksService), new(*webhooksService.WebhooksServiceImpl)),
+	webhooksService.ProvideWebhookDispatcher,
+
+	webhooksRepository.ProvideWebhooksRepository,
+	wire.Bind(new(webhooksRepository.WebhooksRepository), new(*webhooksRepository.WebhooksRepositoryImpl)),
+)
+
+// Wiring for domain notifications
+var domainNotificationsService = wire.NewSet(
+	notificationsService.ProvideNotificationsService,
+	wire.Bind(new(notificationsService.NotificationsService), new(*notificationsService.NotificationsServiceImpl)),
+
+	notificationsRepository.ProvideNotificationsRepository,
+	wire.Bind(new(notificationsRepository.NotificationsRepository), new(*notificationsRepository.NotificationsRepositoryImpl)),
+)
+
 // Wiring for all domains
 var domainsServices = wire.NewSet(
 	domainUsersService,
+	domainExpensesService,
+	domainPurchasingService,
+	domainMortalityService,
+	domainProcessingService,
+	domainAuditService,
+	domainWebhooksService,
+	domainNotificationsService,
+)
+
+// Wiring for scheduled jobs
+var scheduledJobs = wire.NewSet(
+	wire.Struct(new(jobs.DomainJobs), "*"),
+	jobs.ProvideScheduler,
 )
 
 // Wiring for HTTP routing
 var httpRouting = wire.NewSet(
 	wire.Struct(new(router.DomainHandlers), "*"),
 	usersHandlers.ProvideUsersHandler,
+	usersHandlers.ProvideExpensesHandler,
+	usersHandlers.ProvidePurchasingHandler,
+	usersHandlers.ProvideMortalityHandler,
+	usersHandlers.ProvideProcessingHandler,
+	usersHandlers.ProvideAuditHandler,
+	usersHandlers.ProvideWebhooksHandler,
+	usersHandlers.ProvideNotificationsHandler,
+	middleware.ProvideRateLimiter,
+	middleware.ProvideAuthenticator,
 	router.ProvideRouter,
 )
 
 // Wiring for everything.
-func InitializeApp() *http.HTTP {
+func InitializeApp() (*http.HTTP, error) {
 	wire.Build(
 		configurationsService,
 		persistencesService,
 		domainsServices,
+		scheduledJobs,
 		httpRouting,
 		http.ProvideHTTP,
 	)
-	return &http.HTTP{}
+	return &http.HTTP{}, nil
 }
@@ -10,45 +10,151 @@ import (
 	"github.com/google/wire"
 	"github.com/sanika-farm/sanika-farm-be/configs"
 	"github.com/sanika-farm/sanika-farm-be/infras"
+	repository6 "github.com/sanika-farm/sanika-farm-be/internal/domain/audit/repository"
+	services6 "github.com/sanika-farm/sanika-farm-be/internal/domain/audit/services"
+	repository2 "github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/repository"
+	services2 "github.com/sanika-farm/sanika-farm-be/internal/domain/expenses/services"
+	repository4 "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/repository"
+	services4 "github.com/sanika-farm/sanika-farm-be/internal/domain/mortality/services"
+	repository8 "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/repository"
+	services8 "github.com/sanika-farm/sanika-farm-be/internal/domain/notifications/services"
+	repository5 "github.com/sanika-farm/sanika-farm-be/internal/domain/processing/repository"
+	services5 "github.com/sanika-farm/sanika-farm-be/internal/domain/processing/services"
+	repository3 "github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/repository"
+	services3 "github.com/sanika-farm/sanika-farm-be/internal/domain/purchasing/services"
 	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/repository"
 	"github.com/sanika-farm/sanika-farm-be/internal/domain/users/services"
+	repository7 "github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/repository"
+	services7 "github.com/sanika-farm/sanika-farm-be/internal/domain/webhooks/services"
 	"github.com/sanika-farm/sanika-farm-be/internal/handlers"
+	"github.com/sanika-farm/sanika-farm-be/internal/jobs"
 	"github.com/sanika-farm/sanika-farm-be/transports/http"
+	"github.com/sanika-farm/sanika-farm-be/transports/http/middleware"
 	"github.com/sanika-farm/sanika-farm-be/transports/http/router"
 )
 
 // Injectors from wire.go:
 
 // Wiring for everything.
-func InitializeApp() *http.HTTP {
-	config := configs.Get()
-	postgresConn := infras.ProvidePostgresConn(config)
+func InitializeApp() (*http.HTTP, error) {
+	config, err := configs.Get()
+	if err != nil {
+		return nil, err
+	}
+	postgresConn, err := infras.ProvidePostgresConn(config)
+	if err != nil {
+		return nil, err
+	}
 	usersRepositoryImpl := repository.ProvideUsersRepository(postgresConn)
-	usersServiceImpl := services.ProvideUsersService(usersRepositoryImpl, config)
+	redisConn := infras.ProvideRedisConn(config)
+	cache := infras.ProvideCache(redisConn)
+	registry := infras.ProvideMetricsRegistry(postgresConn)
+	scheduler := infras.ProvideScheduler(postgresConn, config, registry)
+	issuer := infras.ProvideTokenIssuer(config)
+	usersServiceImpl := services.ProvideUsersService(usersRepositoryImpl, config, cache, issuer, registry)
 	usersHandler := handlers.ProvideUsersHandler(usersServiceImpl)
+	expensesRepositoryImpl := repository2.ProvideExpensesRepository(postgresConn)
+	expensesServiceImpl := services2.ProvideExpensesService(expensesRepositoryImpl, config)
+	expensesHandler := handlers.ProvideExpensesHandler(expensesServiceImpl)
+	purchasingRepositoryImpl := repository3.ProvidePurchasingRepository(postgresConn)
+	purchasingServiceImpl := services3.ProvidePurchasingService(purchasingRepositoryImpl, config)
+	purchasingHandler := handlers.ProvidePurchasingHandler(purchasingServiceImpl)
+	mortalityRepositoryImpl := repository4.ProvideMortalityRepository(postgresConn)
+	mortalityServiceImpl := services4.ProvideMortalityService(mortalityRepositoryImpl, config, registry)
+	mortalityHandler := handlers.ProvideMortalityHandler(mortalityServiceImpl)
+	processingRepositoryImpl := repository5.ProvideProcessingRepository(postgresConn)
+	processingServiceImpl := services5.ProvideProcessingService(processingRepositoryImpl, config)
+	processingHandler := handlers.ProvideProcessingHandler(processingServiceImpl)
+	auditRepositoryImpl := repository6.ProvideAuditRepository(postgresConn)
+	auditServiceImpl := services6.ProvideAuditService(auditRepositoryImpl, config)
+	auditHandler := handlers.ProvideAuditHandler(auditServiceImpl)
+	webhooksRepositoryImpl := repository7.ProvideWebhooksRepository(postgresConn)
+	bus := infras.ProvideEventBus()
+	webhooksServiceImpl := services7.ProvideWebhooksService(webhooksRepositoryImpl, config, bus)
+	webhooksHandler := handlers.ProvideWebhooksHandler(webhooksServiceImpl)
+	notificationsRepositoryImpl := repository8.ProvideNotificationsRepository(postgresConn)
+	notificationsServiceImpl, err := services8.ProvideNotificationsService(notificationsRepositoryImpl, config, bus, registry)
+	if err != nil {
+		return nil, err
+	}
+	notificationsHandler := handlers.ProvideNotificationsHandler(notificationsServiceImpl)
 	domainHandlers := router.DomainHandlers{
-		UsersHandler: usersHandler,
+		UsersHandler:         usersHandler,
+		ExpensesHandler:      expensesHandler,
+		PurchasingHandler:    purchasingHandler,
+		MortalityHandler:     mortalityHandler,
+		ProcessingHandler:    processingHandler,
+		AuditHandler:         auditHandler,
+		WebhooksHandler:      webhooksHandler,
+		NotificationsHandler: notificationsHandler,
+	}
+	watcher := configs.Watch(config)
+	rateLimiter := middleware.ProvideRateLimiter(config, watcher, redisConn, registry)
+	authenticator := middleware.ProvideAuthenticator(config, issuer)
+	routerRouter := router.ProvideRouter(domainHandlers, rateLimiter, authenticator)
+	healthRegistry := infras.ProvideHealthRegistry(postgresConn, redisConn)
+	tracerProvider, err := infras.ProvideTracer(config)
+	if err != nil {
+		return nil, err
 	}
-	routerRouter := router.ProvideRouter(domainHandlers)
-	httpHTTP := http.ProvideHTTP(postgresConn, config, routerRouter)
-	return httpHTTP
+	reporter := infras.ProvideErrorReporter(config)
+	outboxRelay := infras.ProvideOutboxRelay(postgresConn, bus, config, registry)
+	dispatcher := services7.ProvideWebhookDispatcher(webhooksRepositoryImpl, config, registry)
+	domainJobs := jobs.DomainJobs{
+		MortalityService:     mortalityServiceImpl,
+		NotificationsService: notificationsServiceImpl,
+	}
+	jobsScheduler := jobs.ProvideScheduler(scheduler, domainJobs)
+	httpHTTP := http.ProvideHTTP(postgresConn, redisConn, config, routerRouter, healthRegistry, registry, tracerProvider, reporter, watcher, outboxRelay, dispatcher, jobsScheduler)
+	return httpHTTP, nil
 }
 
 // wire.go:
 
 // Wiring for configurations.
-var configurationsService = wire.NewSet(configs.Get)
+var configurationsService = wire.NewSet(configs.Get, configs.Watch)
 
 // Wiring for persistences.
-var persistencesService = wire.NewSet(infras.ProvidePostgresConn)
+var persistencesService = wire.NewSet(infras.ProvidePostgresConn, infras.ProvideRedisConn, infras.ProvideCache, infras.ProvideMetricsRegistry, infras.ProvideHealthRegistry, infras.ProvideTracer, infras.ProvideErrorReporter, infras.ProvideEventBus, infras.ProvideOutboxRelay, infras.ProvideScheduler, infras.ProvideTokenIssuer)
 
 // Wiring for domain users
 var domainUsersService = wire.NewSet(services.ProvideUsersService, wire.Bind(new(services.UsersService), new(*services.UsersServiceImpl)), repository.ProvideUsersRepository, wire.Bind(new(repository.UsersRepository), new(*repository.UsersRepositoryImpl)))
 
+// Wiring for domain expenses
+var domainExpensesService = wire.NewSet(services2.ProvideExpensesService, wire.Bind(new(services2.ExpensesService), new(*services2.ExpensesServiceImpl)), repository2.ProvideExpensesRepository, wire.Bind(new(repository2.ExpensesRepository), new(*repository2.ExpensesRepositoryImpl)))
+
+// Wiring for domain purchasing
+var domainPurchasingService = wire.NewSet(services3.ProvidePurchasingService, wire.Bind(new(services3.PurchasingService), new(*services3.PurchasingServiceImpl)), repository3.ProvidePurchasingRepository, wire.Bind(new(repository3.PurchasingRepository), new(*repository3.PurchasingRepositoryImpl)))
+
+// Wiring for domain mortality
+var domainMortalityService = wire.NewSet(services4.ProvideMortalityService, wire.Bind(new(services4.MortalityService), new(*services4.MortalityServiceImpl)), repository4.ProvideMortalityRepository, wire.Bind(new(repository4.MortalityRepository), new(*repository4.MortalityRepositoryImpl)))
+
+// Wiring for domain processing
+var domainProcessingService = wire.NewSet(services5.ProvideProcessingService, wire.Bind(new(services5.ProcessingService), new(*services5.ProcessingServiceImpl)), repository5.ProvideProcessingRepository, wire.Bind(new(repository5.ProcessingRepository), new(*repository5.ProcessingRepositoryImpl)))
+
+// Wiring for domain audit
+var domainAuditService = wire.NewSet(services6.ProvideAuditService, wire.Bind(new(services6.AuditService), new(*services6.AuditServiceImpl)), repository6.ProvideAuditRepository, wire.Bind(new(repository6.AuditRepository), new(*repository6.AuditRepositoryImpl)))
+
+// Wiring for domain webhooks
+var domainWebhooksService = wire.NewSet(services7.ProvideWebhooksService, wire.Bind(new(services7.WebhooksService), new(*services7.WebhooksServiceImpl)), services7.ProvideWebhookDispatcher, repository7.ProvideWebhooksRepository, wire.Bind(new(repository7.WebhooksRepository), new(*repository7.WebhooksRepositoryImpl)))
+
+// Wiring for domain notifications
+var domainNotificationsService = wire.NewSet(services8.ProvideNotificationsService, wire.Bind(new(services8.NotificationsService), new(*services8.NotificationsServiceImpl)), repository8.ProvideNotificationsRepository, wire.Bind(new(repository8.NotificationsRepository), new(*repository8.NotificationsRepositoryImpl)))
+
 // Wiring for all domains
 var domainsServices = wire.NewSet(
 	domainUsersService,
+	domainExpensesService,
+	domainPurchasingService,
+	domainMortalityService,
+	domainProcessingService,
+	domainAuditService,
+	domainWebhooksService,
+	domainNotificationsService,
 )
 
+// Wiring for scheduled jobs
+var scheduledJobs = wire.NewSet(wire.Struct(new(jobs.DomainJobs), "*"), jobs.ProvideScheduler)
+
 // Wiring for HTTP routing
-var httpRouting = wire.NewSet(wire.Struct(new(router.DomainHandlers), "*"), handlers.ProvideUsersHandler, router.ProvideRouter)
+var httpRouting = wire.NewSet(wire.Struct(new(router.DomainHandlers), "*"), handlers.ProvideUsersHandler, handlers.ProvideExpensesHandler, handlers.ProvidePurchasingHandler, handlers.ProvideMortalityHandler, handlers.ProvideProcessingHandler, handlers.ProvideAuditHandler, handlers.ProvideWebhooksHandler, handlers.ProvideNotificationsHandler, middleware.ProvideRateLimiter, middleware.ProvideAuthenticator, router.ProvideRouter)